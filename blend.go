@@ -46,6 +46,35 @@ func Blend(bt BlendTypes, p float32, x, y color.Color) color.RGBA {
 	return color.RGBA{}
 }
 
+// BlendPremult returns a color that is the given proportion between the first
+// and second color like [Blend], except that the colors are interpolated with
+// premultiplied alpha, as specified by CSS and SVG for gradients. This means
+// that the contribution of each color is weighted by its alpha value, so that,
+// for example, blending a color with a fully transparent color only changes
+// the alpha value, instead of producing a fringe of the transparent color
+// (which is typically black). The alpha value is interpolated linearly.
+// If both colors have the same alpha value, the result is the same as [Blend].
+func BlendPremult(bt BlendTypes, p float32, x, y color.Color) color.RGBA {
+	fx := NRGBAF32Model.Convert(x).(NRGBAF32)
+	fy := NRGBAF32Model.Convert(y).(NRGBAF32)
+	if fx.A == fy.A {
+		return Blend(bt, p, x, y)
+	}
+	p = mat32.Clamp(p, 0, 100)
+	px := p / 100
+	py := 1 - px
+	a := px*fx.A + py*fy.A
+	if a == 0 {
+		return color.RGBA{}
+	}
+	// the proportion of the first color weighted by its alpha
+	wp := 100 * px * fx.A / a
+	fx.A, fy.A = 1, 1
+	res := NRGBAF32Model.Convert(Blend(bt, wp, fx, fy)).(NRGBAF32)
+	res.A = a
+	return AsRGBA(res)
+}
+
 // BlendRGB returns a color that is the given proportion between the first
 // and second color in RGB colorspace. For example, 0.1 indicates to blend
 // 10% of the first color and 90% of the second. Blending is done directly
@@ -7,6 +7,7 @@ package colors
 import (
 	"fmt"
 	"image"
+	"image/color"
 	"image/draw"
 	"testing"
 
@@ -39,3 +40,35 @@ func TestAlphaBlend(t *testing.T) {
 		images.Assert(t, img, fnm)
 	}
 }
+
+func TestBlendPremult(t *testing.T) {
+	for _, bt := range BlendTypesValues() {
+		// blending with transparent only changes the alpha value
+		for _, p := range []float32{25, 50, 75} {
+			c := BlendPremult(bt, p, Red, Transparent)
+			want := WithAF32(Red, p/100)
+			if absDiff(c.R, want.R) > 1 || c.G > 1 || c.B > 1 || absDiff(c.A, want.A) > 1 {
+				t.Errorf("%v %g%%: expected %v but got %v", bt, p, want, c)
+			}
+		}
+		// opaque colors are the same as with Blend
+		if c, want := BlendPremult(bt, 30, Red, Blue), Blend(bt, 30, Red, Blue); c != want {
+			t.Errorf("%v: expected %v but got %v", bt, want, c)
+		}
+	}
+	if c := BlendPremult(RGB, 50, Transparent, Transparent); c != (color.RGBA{}) {
+		t.Errorf("expected transparent but got %v", c)
+	}
+	// half-transparent blue contributes half as much to the color as opaque red
+	c := BlendPremult(RGB, 50, Red, WithAF32(Blue, 0.5))
+	if want := FromNRGBAF32(2.0/3, 0, 1.0/3, 0.75); absDiff(c.R, want.R) > 1 || absDiff(c.B, want.B) > 1 || absDiff(c.A, want.A) > 1 {
+		t.Errorf("expected %v but got %v", want, c)
+	}
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
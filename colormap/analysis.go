@@ -0,0 +1,167 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+
+	"goki.dev/cam/cam16"
+	"goki.dev/cam/cie"
+	"goki.dev/colors"
+	"goki.dev/colors/oklab"
+	"goki.dev/mat32/v2"
+)
+
+// Spaces are the perceptually uniform colorspaces that can be used
+// for analyzing color maps.
+type Spaces int32 //enums:enum
+
+const (
+	// CAM16UCS is the CAM16-UCS colorspace, in which lightness
+	// is in the range 0-100 and a difference of about 1 is barely noticeable.
+	CAM16UCS Spaces = iota
+
+	// OKLab is the OKLab colorspace (see [oklab.OKLab]). Its values are
+	// scaled by 100 so that they are on a similar scale as [CAM16UCS],
+	// with lightness in the range 0-100 and a difference of about 2
+	// being barely noticeable.
+	OKLab
+)
+
+// Analysis contains the results of analyzing the perceptual linearity of a
+// color map with [Map.Analyze], which can be asserted on in tests and plotted.
+type Analysis struct {
+	// the colorspace that the analysis was done in
+	Space Spaces
+
+	// the values in the range 0-1 at which the color map was sampled
+	Values []float32
+
+	// the perceptual lightness of the color map at each of the Values,
+	// in the range 0-100
+	Lightness []float32
+
+	// the perceptual color difference between each pair of adjacent Values,
+	// of which there is one fewer than the number of Values; it is the
+	// Euclidean distance in the Space, unlike [colors.DeltaE], which
+	// compresses large differences. In a perceptually uniform map,
+	// these are all the same.
+	Distance []float32
+
+	// the mean of Distance
+	MeanDistance float32
+
+	// the maximum of Distance
+	MaxDistance float32
+
+	// the coefficient of variation (standard deviation divided by mean) of
+	// Distance, which is 0 for a perfectly perceptually uniform map
+	Uniformity float32
+
+	// whether Lightness never decreases or never increases,
+	// which is expected for [Sequential] maps
+	Monotonic bool
+}
+
+// Analyze analyzes the perceptual linearity of the color map by sampling it at
+// the given number of evenly spaced values from 0 to 1 in the given colorspace.
+// The number of samples must be at least 2.
+func (cm *Map) Analyze(n int, sp Spaces) *Analysis {
+	n = max(n, 2)
+	a := &Analysis{Space: sp}
+	var prev [3]float32
+	inc, dec := true, true
+	for i := 0; i < n; i++ {
+		v := float32(i) / float32(n-1)
+		c := ucs(cm.Map(v), sp)
+		a.Values = append(a.Values, v)
+		a.Lightness = append(a.Lightness, c[0])
+		if i > 0 {
+			d := c[0] - prev[0]
+			inc = inc && d >= -1e-3
+			dec = dec && d <= 1e-3
+			de := ucsDistance(prev, c)
+			a.Distance = append(a.Distance, de)
+			a.MeanDistance += de
+			a.MaxDistance = max(a.MaxDistance, de)
+		}
+		prev = c
+	}
+	a.Monotonic = inc || dec
+	a.MeanDistance /= float32(n - 1)
+	if a.MeanDistance > 0 {
+		var ss float32
+		for _, de := range a.Distance {
+			ss += (de - a.MeanDistance) * (de - a.MeanDistance)
+		}
+		a.Uniformity = mat32.Sqrt(ss/float32(n-1)) / a.MeanDistance
+	}
+	return a
+}
+
+// LightnessProfile returns the perceptual lightness (0-100) of the color map
+// at the given number of evenly spaced values from 0 to 1 in the given colorspace.
+func (cm *Map) LightnessProfile(n int, sp Spaces) []float32 {
+	return cm.Analyze(n, sp).Lightness
+}
+
+// PerceptualDerivative returns the perceptual color difference (the Euclidean
+// distance in the given colorspace; see [Analysis.Distance]) between each pair
+// of adjacent values of the given number of evenly spaced values from 0 to 1
+// in the color map.
+func (cm *Map) PerceptualDerivative(n int, sp Spaces) []float32 {
+	return cm.Analyze(n, sp).Distance
+}
+
+// IsMonotonic returns whether the perceptual lightness of the color map never
+// decreases or never increases, sampled at the given number of evenly spaced
+// values from 0 to 1 in the given colorspace.
+func (cm *Map) IsMonotonic(n int, sp Spaces) bool {
+	return cm.Analyze(n, sp).Monotonic
+}
+
+// Grayscale returns a new color map with each of the colors of this map
+// converted into the gray with the same relative luminance (and therefore
+// the same CIE L* lightness), which shows how the map looks when printed in
+// grayscale or viewed by someone with achromatopsia. The name of the new
+// map is the name of this map with a _gray suffix.
+func (cm *Map) Grayscale() *Map {
+	nm := cm.Clone()
+	nm.Name = cm.Name + "_gray"
+	for i, c := range nm.Colors {
+		nm.Colors[i] = grayscale(c)
+	}
+	nm.NoColor = grayscale(cm.NoColor)
+	return nm
+}
+
+// grayscale returns the gray with the same relative luminance as the given color.
+func grayscale(c color.RGBA) color.RGBA {
+	f := colors.NRGBAF32Model.Convert(c).(colors.NRGBAF32)
+	rl, gl, bl := cie.SRGBToLinear(f.R, f.G, f.B)
+	y := 0.2126*rl + 0.7152*gl + 0.0722*bl
+	g := cie.SRGBFmLinearComp(y)
+	return fromFloats(g, g, g, f.A)
+}
+
+// ucs returns the lightness and opponent axis values of the given
+// color in the given colorspace, ignoring alpha.
+func ucs(c color.RGBA, sp Spaces) [3]float32 {
+	f := colors.NRGBAF32Model.Convert(c).(colors.NRGBAF32)
+	switch sp {
+	case OKLab:
+		l, a, b := oklab.SRGBToOKLab(f.R, f.G, f.B)
+		return [3]float32{100 * l, 100 * a, 100 * b}
+	default:
+		j, _, a, b := cam16.FromSRGB(f.R, f.G, f.B).UCS()
+		return [3]float32{j, a, b}
+	}
+}
+
+// ucsDistance returns the Euclidean distance between the given colorspace values.
+func ucsDistance(x, y [3]float32) float32 {
+	d0, d1, d2 := x[0]-y[0], x[1]-y[1], x[2]-y[2]
+	return mat32.Sqrt(d0*d0 + d1*d1 + d2*d2)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestAnalyze(t *testing.T) {
+	for _, sp := range SpacesValues() {
+		v := stdMaps["Viridis"].Analyze(64, sp)
+		if len(v.Lightness) != 64 || len(v.Distance) != 63 {
+			t.Fatalf("%v: expected 64 lightness and 63 distance values but got %d and %d", sp, len(v.Lightness), len(v.Distance))
+		}
+		if !v.Monotonic || v.Uniformity > 0.2 {
+			t.Errorf("%v: expected Viridis to be monotonic and uniform but got %v and %g", sp, v.Monotonic, v.Uniformity)
+		}
+		j := stdMaps["Jet"].Analyze(64, sp)
+		if j.Monotonic || j.Uniformity < 2*v.Uniformity {
+			t.Errorf("%v: expected Jet to not be monotonic or uniform but got %v and %g", sp, j.Monotonic, j.Uniformity)
+		}
+		if stdMaps["DarkLightDark"].IsMonotonic(16, sp) {
+			t.Errorf("%v: expected DarkLightDark to not be monotonic", sp)
+		}
+		l := stdMaps["Greys"].LightnessProfile(2, sp)
+		if mat32.Abs(l[0]-100) > 1 || mat32.Abs(l[1]) > 1 {
+			t.Errorf("%v: expected Greys lightness from 100 to 0 but got %v", sp, l)
+		}
+	}
+}
+
+func TestGrayscale(t *testing.T) {
+	g := stdMaps["Jet"].Grayscale()
+	if g.Name != "Jet_gray" {
+		t.Errorf("expected name Jet_gray but got %q", g.Name)
+	}
+	for i, c := range g.Colors {
+		if c.R != c.G || c.G != c.B {
+			t.Errorf("expected gray but got %v", c)
+		}
+		want := ucs(stdMaps["Jet"].Colors[i], CAM16UCS)[0]
+		have := ucs(c, CAM16UCS)[0]
+		// CAM16 lightness is not exactly CIE L*, but it should be close
+		if mat32.Abs(want-have) > 5 {
+			t.Errorf("expected lightness %g but got %g", want, have)
+		}
+	}
+	if c := grayscale(colors.White); c != colors.White {
+		t.Errorf("expected white to stay white but got %v", c)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"sort"
+
+	"goki.dev/mat32/v2"
+)
+
+// Binned maps continuous raw values onto a discrete set of colors,
+// one for each bin (class) of values defined by a list of boundaries.
+// It is typically made with [NewBinned].
+type Binned struct {
+	// the boundaries between the bins in increasing order, of which there
+	// must be one fewer than the number of colors; a value that is equal
+	// to a boundary is in the bin above it. Values below the first boundary
+	// are in the first bin, and values at or above the last boundary are
+	// in the last bin.
+	Boundaries []float32
+
+	// the color of each bin
+	Colors []color.RGBA
+
+	// color to display for invalid numbers (e.g., NaN)
+	NoColor color.RGBA
+}
+
+// NewBinned returns a new [Binned] with the given boundaries and colors
+// sampled from the given color map (see [Map.Sample]), with one more
+// color than the number of boundaries.
+func NewBinned(cm *Map, boundaries ...float32) *Binned {
+	return &Binned{
+		Boundaries: boundaries,
+		Colors:     cm.Sample(len(boundaries) + 1),
+		NoColor:    cm.NoColor,
+	}
+}
+
+// EqualBins returns the boundaries for the given number of bins of
+// equal size from the given min to max, which can be passed to [NewBinned].
+func EqualBins(min, max float32, n int) []float32 {
+	if n < 2 {
+		return nil
+	}
+	bs := make([]float32, n-1)
+	for i := range bs {
+		bs[i] = min + (max-min)*float32(i+1)/float32(n)
+	}
+	return bs
+}
+
+// Bin returns the index of the bin that the given value is in, or -1 for NaN.
+func (b *Binned) Bin(val float32) int {
+	if mat32.IsNaN(val) {
+		return -1
+	}
+	return sort.Search(len(b.Boundaries), func(i int) bool {
+		return b.Boundaries[i] > val
+	})
+}
+
+// Map returns the color of the bin that the given value is in.
+// NaN values and bins without a color return NoColor.
+func (b *Binned) Map(val float32) color.RGBA {
+	bin := b.Bin(val)
+	if bin < 0 || bin >= len(b.Colors) {
+		return b.NoColor
+	}
+	return b.Colors[bin]
+}
+
+// Sample returns the given number of colors evenly spaced
+// from 0 to 1 in the map. If n is 1, it returns the center color.
+// For a [Map.Indexed] map, it returns the first n colors of the map
+// (see [Map.MapIndex]), repeating them if there are fewer than n.
+func (cm *Map) Sample(n int) []color.RGBA {
+	cs := make([]color.RGBA, max(n, 0))
+	if cm.Indexed {
+		for i := range cs {
+			cs[i] = cm.MapIndex(i % max(len(cm.Colors), 1))
+		}
+		return cs
+	}
+	if n == 1 {
+		cs[0] = cm.Map(0.5)
+		return cs
+	}
+	for i := range cs {
+		cs[i] = cm.Map(float32(i) / float32(n-1))
+	}
+	return cs
+}
+
+// Discrete returns a new [Map.Indexed] map with the given number of
+// colors sampled from the map (see [Map.Sample]).
+// The other properties of the map are copied from this map.
+func (cm *Map) Discrete(n int) *Map {
+	nm := cm.Clone()
+	nm.Indexed = true
+	nm.Colors = cm.Sample(n)
+	nm.Positions = nil
+	return nm
+}
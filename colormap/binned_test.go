@@ -0,0 +1,81 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestMapIndex(t *testing.T) {
+	cm := stdMaps["Set1"]
+	if c := cm.MapIndex(len(cm.Colors)); c != cm.NoColor {
+		t.Errorf("expected NoColor for index out of range but got %v", c)
+	}
+	if c := cm.MapIndex(0); c != cm.Colors[0] {
+		t.Errorf("expected first color but got %v", c)
+	}
+}
+
+func TestBinned(t *testing.T) {
+	cm := &Map{
+		Blend:   colors.RGB,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Colors:  []color.RGBA{colors.Black, colors.White},
+	}
+	bs := EqualBins(0, 30, 3)
+	if !slices.Equal(bs, []float32{10, 20}) {
+		t.Fatalf("expected boundaries [10 20] but got %v", bs)
+	}
+	b := NewBinned(cm, bs...)
+	tests := []struct {
+		val  float32
+		want color.RGBA
+	}{
+		{-5, colors.Black},
+		{9.9, colors.Black},
+		{10, color.RGBA{128, 128, 128, 255}},
+		{19, color.RGBA{128, 128, 128, 255}},
+		{20, colors.White},
+		{100, colors.White},
+		{mat32.NaN(), cm.NoColor},
+	}
+	for _, test := range tests {
+		have := b.Map(test.val)
+		if absDiff(have.R, test.want.R) > 1 || have.A != test.want.A {
+			t.Errorf("%g: expected %v but got %v", test.val, test.want, have)
+		}
+	}
+}
+
+func TestDiscrete(t *testing.T) {
+	d := stdMaps["Viridis"].Discrete(5)
+	if !d.Indexed || len(d.Colors) != 5 {
+		t.Fatalf("expected indexed map with 5 colors but got %v", d.Colors)
+	}
+	if d.Colors[0] != stdMaps["Viridis"].Colors[0] || d.Colors[4] != stdMaps["Viridis"].Colors[255] {
+		t.Errorf("expected discrete colors to include the ends of the map")
+	}
+	if len(stdMaps["Viridis"].Colors) != 256 || stdMaps["Viridis"].Indexed {
+		t.Errorf("expected original map to be unchanged")
+	}
+
+	// indexed maps take their colors in order instead of blending them
+	set1 := stdMaps["Set1"]
+	d = set1.Discrete(3)
+	if !slices.Equal(d.Colors, set1.Colors[:3]) {
+		t.Errorf("expected the first 3 colors of Set1 but got %v", d.Colors)
+	}
+	if cs := set1.Sample(11); cs[9] != set1.Colors[0] || cs[10] != set1.Colors[1] {
+		t.Errorf("expected the colors of Set1 to repeat but got %v", cs)
+	}
+	if b := NewBinned(set1, 0.5); b.Colors[1] != set1.Colors[1] {
+		t.Errorf("expected the second color of Set1 in the second bin but got %v", b.Colors[1])
+	}
+}
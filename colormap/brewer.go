@@ -0,0 +1,42 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+
+	"goki.dev/colors"
+	"goki.dev/grr"
+)
+
+// The ColorBrewer maps are by Cynthia A. Brewer, Geography, Pennsylvania
+// State University (https://colorbrewer2.org) and are licensed under the
+// Apache License, Version 2.0. The sequential maps use the 9-class
+// versions, the diverging maps use the 11-class versions, and the
+// qualitative maps use the largest available versions. The colorblind
+// safety flags are the ColorBrewer ratings for those numbers of classes;
+// some maps, such as Dark2, Paired, and Set2, are only rated colorblind
+// safe with fewer classes.
+
+// brewerMap returns a new map with the given name, kind, colorblind
+// safety, and colors specified as hex strings. Qualitative maps
+// are [Map.Indexed].
+func brewerMap(name string, kind Kinds, cvd bool, hex ...string) *Map {
+	cm := &Map{
+		Name:    name,
+		Source:  "ColorBrewer",
+		License: "Apache-2.0",
+		Indexed: kind == Qualitative,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Blend:   colors.RGB,
+		Kind:    kind,
+		CVDSafe: cvd,
+		Colors:  make([]color.RGBA, len(hex)),
+	}
+	for i, h := range hex {
+		cm.Colors[i] = grr.Must1(colors.FromHex(h))
+	}
+	return cm
+}
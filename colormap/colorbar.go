@@ -0,0 +1,167 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image"
+	"image/color"
+
+	"goki.dev/colors/gradient"
+)
+
+// Colorbar contains the options for rendering a color map into an image
+// as a colorbar (a legend for the color map). It is typically made with
+// [NewColorbar] or [Scale.Colorbar], and rendered with [Colorbar.Image].
+type Colorbar struct {
+	// the color map to render
+	Colormap *Map
+
+	// the length of the colorbar along the color map, in pixels, not
+	// including the NoColor swatch and the under and over triangles
+	Length int
+
+	// the thickness of the colorbar across the color map, in pixels, which
+	// is also the size of the NoColor swatch and under and over triangles
+	Thickness int
+
+	// whether the colorbar is vertical, in which case low values are at
+	// the bottom, instead of horizontal, in which case low values are at
+	// the left
+	Vertical bool
+
+	// whether to render a swatch of the NoColor of the map before the
+	// start of the colorbar, separated from it by a small gap
+	NoColor bool
+
+	// if non-nil, a triangle of this color is rendered before the start of
+	// the colorbar to indicate the color used for values below the range
+	Under *color.RGBA
+
+	// if non-nil, a triangle of this color is rendered after the end of
+	// the colorbar to indicate the color used for values above the range
+	Over *color.RGBA
+}
+
+// NewColorbar returns a new horizontal [Colorbar] for the given color map
+// with the given length and thickness.
+func NewColorbar(cm *Map, length, thickness int) *Colorbar {
+	return &Colorbar{Colormap: cm, Length: length, Thickness: thickness}
+}
+
+// Colorbar returns a new horizontal [Colorbar] for the color map of the scale
+// with the given length and thickness. If the scale does not clip values, the
+// colorbar has triangles with the under and over colors of the scale.
+func (s *Scale) Colorbar(length, thickness int) *Colorbar {
+	cb := NewColorbar(s.Colormap, length, thickness)
+	if !s.Clip {
+		cb.Under, cb.Over = &s.Under, &s.Over
+	}
+	return cb
+}
+
+// Image renders the colorbar into a new image with a transparent background.
+// For a [Map.Indexed] map, each color is rendered as a band of equal size.
+func (cb *Colorbar) Image() *image.RGBA {
+	th := cb.Thickness
+	gap := th / 4
+	// start of each part along the colorbar
+	under := 0
+	if cb.NoColor {
+		under = th + gap
+	}
+	bar := under
+	if cb.Under != nil {
+		bar += th
+	}
+	over := bar + cb.Length
+	total := over
+	if cb.Over != nil {
+		total += th
+	}
+
+	var img *image.RGBA
+	if cb.Vertical {
+		img = image.NewRGBA(image.Rect(0, 0, th, total))
+	} else {
+		img = image.NewRGBA(image.Rect(0, 0, total, th))
+	}
+	// set sets the pixel at the given position along and across the colorbar
+	set := func(along, across int, c color.RGBA) {
+		if cb.Vertical {
+			img.SetRGBA(across, total-1-along, c)
+		} else {
+			img.SetRGBA(along, across, c)
+		}
+	}
+	// triangle renders a triangle of the given color starting at the given
+	// position along the colorbar, pointing in the given direction (-1 or 1)
+	triangle := func(start, dir int, c color.RGBA) {
+		for i := 0; i < th; i++ {
+			// distance from the tip of the triangle
+			d := float32(i) + 0.5
+			if dir > 0 {
+				d = float32(th-i) - 0.5
+			}
+			along := start + i
+			for across := 0; across < th; across++ {
+				off := float32(across) + 0.5 - float32(th)/2
+				if off >= -d/2 && off <= d/2 {
+					set(along, across, c)
+				}
+			}
+		}
+	}
+
+	if cb.NoColor {
+		for along := 0; along < th; along++ {
+			for across := 0; across < th; across++ {
+				set(along, across, cb.Colormap.NoColor)
+			}
+		}
+	}
+	if cb.Under != nil {
+		triangle(under, -1, *cb.Under)
+	}
+	nc := len(cb.Colormap.Colors)
+	for i := 0; i < cb.Length; i++ {
+		var c color.RGBA
+		if cb.Colormap.Indexed {
+			c = cb.Colormap.MapIndex(min(i*nc/max(cb.Length, 1), nc-1))
+		} else {
+			c = cb.Colormap.Map(float32(i) / float32(max(cb.Length-1, 1)))
+		}
+		for across := 0; across < th; across++ {
+			set(bar+i, across, c)
+		}
+	}
+	if cb.Over != nil {
+		triangle(over, 1, *cb.Over)
+	}
+	return img
+}
+
+// Gradient returns a new left-to-right [gradient.Linear] with stops for each
+// of the colors of the map, using [Map.Positions] if they are specified and
+// the blend type of the map. For a [Map.Indexed] map, each color is a band of
+// equal size with sharp transitions between the bands.
+func (cm *Map) Gradient() *gradient.Linear {
+	g := gradient.NewLinear()
+	g.Blend = cm.Blend
+	nc := len(cm.Colors)
+	for i, c := range cm.Colors {
+		switch {
+		case cm.Indexed:
+			g.AddStop(c, float32(i)/float32(nc))
+			g.AddStop(c, float32(i+1)/float32(nc))
+		case len(cm.Positions) == nc:
+			g.AddStop(c, cm.Positions[i])
+		case nc == 1:
+			g.AddStop(c, 0)
+		default:
+			g.AddStop(c, float32(i)/float32(nc-1))
+		}
+	}
+	return g
+}
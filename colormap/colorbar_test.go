@@ -0,0 +1,68 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/grows/images"
+)
+
+func TestColorbar(t *testing.T) {
+	s := NewLinearScale(stdMaps["Viridis"], 0, 1)
+	s.Clip = false
+	s.Under, s.Over = colors.FromRGB(255, 0, 255), colors.FromRGB(255, 0, 0)
+
+	hcb := s.Colorbar(256, 24)
+	hcb.NoColor = true
+	h := hcb.Image()
+	if h.Bounds().Dx() != 24+6+24+256+24 || h.Bounds().Dy() != 24 {
+		t.Errorf("unexpected horizontal colorbar size %v", h.Bounds())
+	}
+	if c := h.RGBAAt(2, 2); c != stdMaps["Viridis"].NoColor {
+		t.Errorf("expected NoColor swatch but got %v", c)
+	}
+	if c := h.RGBAAt(24+6+23, 12); c != s.Under {
+		t.Errorf("expected under triangle but got %v", c)
+	}
+	if c := h.RGBAAt(24+6+1, 1); c != (color.RGBA{}) {
+		t.Errorf("expected transparent corner of under triangle but got %v", c)
+	}
+
+	vcb := NewColorbar(stdMaps["Set1"], 180, 24)
+	vcb.Vertical = true
+	v := vcb.Image()
+	if c := v.RGBAAt(12, 179); c != stdMaps["Set1"].Colors[0] {
+		t.Errorf("expected first indexed color at the bottom but got %v", c)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, h.Bounds().Dx(), 24+180))
+	draw.Draw(img, h.Bounds(), h, image.Point{}, draw.Src)
+	draw.Draw(img, v.Bounds().Add(image.Pt(0, 24)), v, image.Point{}, draw.Src)
+	images.Assert(t, img, "colorbar")
+}
+
+func TestMapGradient(t *testing.T) {
+	cm := stdMaps["Viridis"]
+	g := cm.Gradient()
+	if len(g.Stops) != len(cm.Colors) || g.Stops[255].Pos != 1 {
+		t.Fatalf("expected %d stops ending at 1 but got %d", len(cm.Colors), len(g.Stops))
+	}
+	g.Box.Max.X, g.Box.Max.Y = 256, 1
+	g.Update()
+	want := cm.Map(100.5 / 256)
+	if c := colors.AsRGBA(g.At(100, 0)); absDiff(c.G, want.G) > 1 {
+		t.Errorf("expected %v but got %v", want, c)
+	}
+
+	ig := stdMaps["Set1"].Gradient()
+	if len(ig.Stops) != 2*len(stdMaps["Set1"].Colors) {
+		t.Errorf("expected two stops per indexed color but got %d", len(ig.Stops))
+	}
+}
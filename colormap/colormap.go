@@ -4,9 +4,10 @@
 
 package colormap
 
+//go:generate goki generate
+
 import (
 	"image/color"
-	"maps"
 	"sort"
 
 	"goki.dev/colors"
@@ -22,7 +23,9 @@ type Map struct {
 	// if true, this map should be used as an indexed list instead of interpolating a normalized floating point value: requires caller to check this flag and pass int indexes instead of normalized values to MapIndex
 	Indexed bool
 
-	// the colorspace algorithm to use for blending colors
+	// the colorspace algorithm to use for blending colors; this is the
+	// recommended blend type for the map, which is typically [colors.RGB]
+	// for maps that are already defined by a dense list of colors
 	Blend colors.BlendTypes
 
 	// color to display for invalid numbers (e.g., NaN)
@@ -30,15 +33,71 @@ type Map struct {
 
 	// list of colors to interpolate between
 	Colors []color.RGBA
+
+	// optional positions of each of the colors in the range 0-1, in
+	// sorted order; if this is not the same length as Colors, the colors
+	// are evenly spaced from 0 to 1. Two colors at the same position
+	// result in a sharp transition at that position.
+	Positions []float32
+
+	// the kind of data that this map is designed for (sequential, diverging, etc)
+	Kind Kinds
+
+	// whether this map is designed to be distinguishable by people with
+	// color vision deficiency (colorblindness)
+	CVDSafe bool
+
+	// where this map comes from (for example, the name of the project or
+	// person that designed it), if it is not original to this package
+	Source string
+
+	// the license under which this map is distributed, if it is not
+	// original to this package
+	License string
+
+	// lut is the optional precomputed lookup table of colors
+	// evenly spaced from 0 to 1 (see [Map.UseLUT])
+	lut *lookupTable
 }
 
+// Kinds are the kinds of data that color maps are designed for.
+type Kinds int32 //enums:enum
+
+const (
+	// Sequential maps are for ordered data that progresses from low to high,
+	// typically with a monotonic change in lightness.
+	Sequential Kinds = iota
+
+	// Diverging maps are for data with a meaningful midpoint (such as zero),
+	// with two contrasting hues diverging from a neutral center.
+	Diverging
+
+	// Cyclic maps are for data that wraps around, such as angles or phases,
+	// with the same color at both ends.
+	Cyclic
+
+	// Qualitative maps are for categorical data without an inherent order,
+	// and they are typically used in [Map.Indexed] mode.
+	Qualitative
+)
+
 func (cm *Map) String() string {
 	return cm.Name
 }
 
 // Map returns color for normalized value in range 0-1.  NaN returns NoColor
-// which can be used to indicate missing values.
+// which can be used to indicate missing values. If the map has a lookup
+// table (see [Map.UseLUT]), the nearest color in it is returned.
 func (cm *Map) Map(val float32) color.RGBA {
+	if cm.lut != nil {
+		return cm.mapLUT(val)
+	}
+	return cm.mapBlend(val)
+}
+
+// mapBlend returns the color for the given normalized value
+// by blending the colors on either side of it.
+func (cm *Map) mapBlend(val float32) color.RGBA {
 	nc := len(cm.Colors)
 	if nc < 2 {
 		return color.RGBA{}
@@ -46,6 +105,9 @@ func (cm *Map) Map(val float32) color.RGBA {
 	if mat32.IsNaN(val) {
 		return cm.NoColor
 	}
+	if len(cm.Positions) == nc {
+		return cm.mapPositions(val)
+	}
 	if val <= 0 {
 		return cm.Colors[0]
 	} else if val >= 1 {
@@ -63,25 +125,46 @@ func (cm *Map) Map(val float32) color.RGBA {
 	return colors.Blend(cm.Blend, cmix, lclr, uclr)
 }
 
+// mapPositions returns the color for the given value based on [Map.Positions],
+// using a binary search to find the colors on either side of the value.
+func (cm *Map) mapPositions(val float32) color.RGBA {
+	nc := len(cm.Colors)
+	uidx := sort.Search(nc, func(i int) bool {
+		return cm.Positions[i] > val
+	})
+	if uidx == 0 {
+		return cm.Colors[0]
+	} else if uidx == nc {
+		return cm.Colors[nc-1]
+	}
+	lidx := uidx - 1
+	lpos, upos := cm.Positions[lidx], cm.Positions[uidx]
+	cmix := 100 * (1 - (val-lpos)/(upos-lpos))
+	return colors.Blend(cm.Blend, cmix, cm.Colors[lidx], cm.Colors[uidx])
+}
+
 // MapIndex returns color for given index, for scale in Indexed mode.
 // NoColor is returned for values out of range of available colors.
 // It is responsibility of the caller to use this method instead of Map
 // based on the Indexed flag.
 func (cm *Map) MapIndex(val int) color.RGBA {
 	nc := len(cm.Colors)
-	if val < 0 || val > nc {
+	if val < 0 || val >= nc {
 		return cm.NoColor
 	}
 	return cm.Colors[val]
 }
 
 // see https://matplotlib.org/tutorials/colors/colormap-manipulation.html
-// for how to read out matplotlib scales -- still don't understand segmented ones!
+// for how to read out matplotlib scales; segmented ones can be imported
+// with [FromSegmented].
 
-// StdMaps is a list of standard color maps
-var StdMaps = map[string]*Map{
+// stdMaps is a list of standard color maps, which are all registered
+// in the [DefaultRegistry]; they are accessed through it with [Get].
+var stdMaps = map[string]*Map{
 	"ColdHot": {
 		Name:    "ColdHot",
+		Kind:    Diverging,
 		NoColor: colors.FromRGB(200, 200, 200),
 		Colors: []color.RGBA{
 			{0, 255, 255, 255},
@@ -119,60 +202,14 @@ var StdMaps = map[string]*Map{
 			{153, 25, 25, 255},
 		},
 	},
-	"Viridis": {
-		Name:    "Viridis",
-		NoColor: color.RGBA{200, 200, 200, 255},
-		Colors: []color.RGBA{
-			{72, 33, 114, 255},
-			{67, 62, 133, 255},
-			{56, 87, 140, 255},
-			{45, 111, 142, 255},
-			{36, 133, 142, 255},
-			{30, 155, 138, 255},
-			{42, 176, 127, 255},
-			{81, 197, 105, 255},
-			{134, 212, 73, 255},
-			{194, 223, 35, 255},
-			{253, 231, 37, 255},
-		},
-	},
-	"Plasma": {
-		Name:    "Plasma",
-		NoColor: color.RGBA{200, 200, 200, 255},
-		Colors: []color.RGBA{
-			{61, 4, 155, 255},
-			{99, 0, 167, 255},
-			{133, 6, 166, 255},
-			{166, 32, 152, 255},
-			{192, 58, 131, 255},
-			{213, 84, 110, 255},
-			{231, 111, 90, 255},
-			{246, 141, 69, 255},
-			{253, 174, 50, 255},
-			{252, 210, 36, 255},
-			{240, 248, 33, 255},
-		},
-	},
-	"Inferno": {
-		Name:    "Inferno",
-		NoColor: color.RGBA{200, 200, 200, 255},
-		Colors: []color.RGBA{
-			{37, 12, 3, 255},
-			{19, 11, 52, 255},
-			{57, 9, 99, 255},
-			{95, 19, 110, 255},
-			{133, 33, 107, 255},
-			{169, 46, 94, 255},
-			{203, 65, 73, 255},
-			{230, 93, 47, 255},
-			{247, 131, 17, 255},
-			{252, 174, 19, 255},
-			{245, 219, 76, 255},
-			{252, 254, 164, 255},
-		},
-	},
+	"Viridis": tableMap("Viridis", "matplotlib", "CC0-1.0", true, viridisHex),
+	"Magma":   tableMap("Magma", "matplotlib", "CC0-1.0", true, magmaHex),
+	"Inferno": tableMap("Inferno", "matplotlib", "CC0-1.0", true, infernoHex),
+	"Plasma":  tableMap("Plasma", "matplotlib", "CC0-1.0", true, plasmaHex),
+	"Turbo":   tableMap("Turbo", "Google", "Apache-2.0", false, turboHex),
 	"BlueRed": {
 		Name:    "BlueRed",
+		Kind:    Diverging,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{0, 0, 255, 255},
@@ -181,6 +218,7 @@ var StdMaps = map[string]*Map{
 	},
 	"BlueBlackRed": {
 		Name:    "BlueBlackRed",
+		Kind:    Diverging,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{0, 0, 255, 255},
@@ -190,6 +228,7 @@ var StdMaps = map[string]*Map{
 	},
 	"BlueGreyRed": {
 		Name:    "BlueGreyRed",
+		Kind:    Diverging,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{0, 0, 255, 255},
@@ -199,6 +238,7 @@ var StdMaps = map[string]*Map{
 	},
 	"BlueWhiteRed": {
 		Name:    "BlueWhiteRed",
+		Kind:    Diverging,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{0, 0, 255, 255},
@@ -208,6 +248,7 @@ var StdMaps = map[string]*Map{
 	},
 	"BlueGreenRed": {
 		Name:    "BlueGreenRed",
+		Kind:    Diverging,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{0, 0, 255, 255},
@@ -248,6 +289,7 @@ var StdMaps = map[string]*Map{
 	},
 	"DarkLightDark": {
 		Name:    "DarkLightDark",
+		Kind:    Cyclic,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{0, 0, 0, 255},
@@ -256,7 +298,8 @@ var StdMaps = map[string]*Map{
 		},
 	},
 	"LightDarkLight": {
-		Name:    "DarkLightDark",
+		Name:    "LightDarkLight",
+		Kind:    Cyclic,
 		NoColor: color.RGBA{200, 200, 200, 255},
 		Colors: []color.RGBA{
 			{250, 250, 250, 255},
@@ -264,23 +307,87 @@ var StdMaps = map[string]*Map{
 			{250, 250, 250, 255},
 		},
 	},
+	"Blues": brewerMap("Blues", Sequential, true,
+		"f7fbff", "deebf7", "c6dbef", "9ecae1", "6baed6", "4292c6", "2171b5", "08519c", "08306b"),
+	"Greens": brewerMap("Greens", Sequential, true,
+		"f7fcf5", "e5f5e0", "c7e9c0", "a1d99b", "74c476", "41ab5d", "238b45", "006d2c", "00441b"),
+	"Greys": brewerMap("Greys", Sequential, true,
+		"ffffff", "f0f0f0", "d9d9d9", "bdbdbd", "969696", "737373", "525252", "252525", "000000"),
+	"Oranges": brewerMap("Oranges", Sequential, true,
+		"fff5eb", "fee6ce", "fdd0a2", "fdae6b", "fd8d3c", "f16913", "d94801", "a63603", "7f2704"),
+	"Purples": brewerMap("Purples", Sequential, true,
+		"fcfbfd", "efedf5", "dadaeb", "bcbddc", "9e9ac8", "807dba", "6a51a3", "54278f", "3f007d"),
+	"Reds": brewerMap("Reds", Sequential, true,
+		"fff5f0", "fee0d2", "fcbba1", "fc9272", "fb6a4a", "ef3b2c", "cb181d", "a50f15", "67000d"),
+	"BuPu": brewerMap("BuPu", Sequential, true,
+		"f7fcfd", "e0ecf4", "bfd3e6", "9ebcda", "8c96c6", "8c6bb1", "88419d", "810f7c", "4d004b"),
+	"OrRd": brewerMap("OrRd", Sequential, true,
+		"fff7ec", "fee8c8", "fdd49e", "fdbb84", "fc8d59", "ef6548", "d7301f", "b30000", "7f0000"),
+	"PuBu": brewerMap("PuBu", Sequential, true,
+		"fff7fb", "ece7f2", "d0d1e6", "a6bddb", "74a9cf", "3690c0", "0570b0", "045a8d", "023858"),
+	"YlGn": brewerMap("YlGn", Sequential, true,
+		"ffffe5", "f7fcb9", "d9f0a3", "addd8e", "78c679", "41ab5d", "238443", "006837", "004529"),
+	"YlGnBu": brewerMap("YlGnBu", Sequential, true,
+		"ffffd9", "edf8b1", "c7e9b4", "7fcdbb", "41b6c4", "1d91c0", "225ea8", "253494", "081d58"),
+	"YlOrBr": brewerMap("YlOrBr", Sequential, true,
+		"ffffe5", "fff7bc", "fee391", "fec44f", "fe9929", "ec7014", "cc4c02", "993404", "662506"),
+	"YlOrRd": brewerMap("YlOrRd", Sequential, true,
+		"ffffcc", "ffeda0", "fed976", "feb24c", "fd8d3c", "fc4e2a", "e31a1c", "bd0026", "800026"),
+	"BrBG": brewerMap("BrBG", Diverging, true,
+		"543005", "8c510a", "bf812d", "dfc27d", "f6e8c3", "f5f5f5", "c7eae5", "80cdc1", "35978f", "01665e", "003c30"),
+	"PiYG": brewerMap("PiYG", Diverging, true,
+		"8e0152", "c51b7d", "de77ae", "f1b6da", "fde0ef", "f7f7f7", "e6f5d0", "b8e186", "7fbc41", "4d9221", "276419"),
+	"PRGn": brewerMap("PRGn", Diverging, true,
+		"40004b", "762a83", "9970ab", "c2a5cf", "e7d4e8", "f7f7f7", "d9f0d3", "a6dba0", "5aae61", "1b7837", "00441b"),
+	"PuOr": brewerMap("PuOr", Diverging, true,
+		"7f3b08", "b35806", "e08214", "fdb863", "fee0b6", "f7f7f7", "d8daeb", "b2abd2", "8073ac", "542788", "2d004b"),
+	"RdBu": brewerMap("RdBu", Diverging, true,
+		"67001f", "b2182b", "d6604d", "f4a582", "fddbc7", "f7f7f7", "d1e5f0", "92c5de", "4393c3", "2166ac", "053061"),
+	"RdGy": brewerMap("RdGy", Diverging, false,
+		"67001f", "b2182b", "d6604d", "f4a582", "fddbc7", "ffffff", "e0e0e0", "bababa", "878787", "4d4d4d", "1a1a1a"),
+	"RdYlBu": brewerMap("RdYlBu", Diverging, true,
+		"a50026", "d73027", "f46d43", "fdae61", "fee090", "ffffbf", "e0f3f8", "abd9e9", "74add1", "4575b4", "313695"),
+	"RdYlGn": brewerMap("RdYlGn", Diverging, false,
+		"a50026", "d73027", "f46d43", "fdae61", "fee08b", "ffffbf", "d9ef8b", "a6d96a", "66bd63", "1a9850", "006837"),
+	"Spectral": brewerMap("Spectral", Diverging, false,
+		"9e0142", "d53e4f", "f46d43", "fdae61", "fee08b", "ffffbf", "e6f598", "abdda4", "66c2a5", "3288bd", "5e4fa2"),
+	"Dark2": brewerMap("Dark2", Qualitative, false,
+		"1b9e77", "d95f02", "7570b3", "e7298a", "66a61e", "e6ab02", "a6761d", "666666"),
+	"Paired": brewerMap("Paired", Qualitative, false,
+		"a6cee3", "1f78b4", "b2df8a", "33a02c", "fb9a99", "e31a1c", "fdbf6f", "ff7f00", "cab2d6", "6a3d9a", "ffff99", "b15928"),
+	"Set1": brewerMap("Set1", Qualitative, false,
+		"e41a1c", "377eb8", "4daf4a", "984ea3", "ff7f00", "ffff33", "a65628", "f781bf", "999999"),
+	"Set2": brewerMap("Set2", Qualitative, false,
+		"66c2a5", "fc8d62", "8da0cb", "e78ac3", "a6d854", "ffd92f", "e5c494", "b3b3b3"),
 }
 
-// AvailMaps is the list of all available color maps
-var AvailMaps = map[string]*Map{}
+// StdMaps is a copy of the standard color maps, keyed by name. Modifying
+// it does not affect the [DefaultRegistry].
+//
+// Deprecated: use [Get] and [AvailMapsList] instead.
+var StdMaps = cloneMaps(stdMaps)
 
 func init() {
-	maps.Copy(AvailMaps, StdMaps)
+	for _, cm := range stdMaps {
+		DefaultRegistry.Register(cm)
+	}
 }
 
-// AvailMapsList returns a sorted list of color map names, e.g., for choosers
+// AvailMapsList returns a sorted list of the names of the color maps
+// in the [DefaultRegistry], e.g., for choosers
 func AvailMapsList() []string {
-	sl := make([]string, len(AvailMaps))
-	ctr := 0
-	for k := range AvailMaps {
-		sl[ctr] = k
-		ctr++
-	}
-	sort.Strings(sl)
-	return sl
+	return DefaultRegistry.Names()
+}
+
+// AvailMapsOfKind returns a sorted list of the names of the color maps
+// of the given kind in the [DefaultRegistry], e.g., for grouping maps
+// in choosers.
+func AvailMapsOfKind(kind Kinds) []string {
+	return DefaultRegistry.NamesOfKind(kind)
+}
+
+// AvailMapsByKind returns the sorted names of all of the color maps in
+// the [DefaultRegistry] grouped by their kind (see [AvailMapsOfKind]).
+func AvailMapsByKind() map[Kinds][]string {
+	return DefaultRegistry.NamesByKind()
 }
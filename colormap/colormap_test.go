@@ -16,7 +16,7 @@ import (
 )
 
 func TestColorMaps(t *testing.T) {
-	nmaps := len(StdMaps)
+	nmaps := len(stdMaps)
 	nblend := int(colors.BlendTypesN)
 	// y axis is maps x blend mode
 	nY := nmaps * (nblend + 1)
@@ -31,13 +31,13 @@ func TestColorMaps(t *testing.T) {
 	yp := 0
 	idx := 0
 	keys := make([]string, nmaps)
-	for k := range StdMaps {
+	for k := range stdMaps {
 		keys[idx] = k
 		idx++
 	}
 	slices.Sort(keys)
 	for idx, k := range keys {
-		cm := StdMaps[k]
+		cm := stdMaps[k].Clone()
 		for bi, bm := range colors.BlendTypesValues() {
 			yp = idx*(nblend+1) + bi
 			cm.Blend = bm
@@ -58,3 +58,67 @@ func TestColorMaps(t *testing.T) {
 
 	images.Assert(t, img, "colormaps")
 }
+
+func TestScientificMaps(t *testing.T) {
+	// reference values at the start, center, and end of the maps
+	want := map[string][3]color.RGBA{
+		"Viridis": {{68, 1, 84, 255}, {33, 145, 140, 255}, {253, 231, 37, 255}},
+		"Magma":   {{0, 0, 4, 255}, {183, 55, 121, 255}, {252, 253, 191, 255}},
+		"Inferno": {{0, 0, 4, 255}, {188, 55, 84, 255}, {252, 255, 164, 255}},
+		"Plasma":  {{13, 8, 135, 255}, {204, 71, 120, 255}, {240, 249, 33, 255}},
+		"Turbo":   {{48, 18, 59, 255}, {164, 252, 60, 255}, {122, 4, 3, 255}},
+	}
+	for name, w := range want {
+		cm := stdMaps[name]
+		if len(cm.Colors) != 256 {
+			t.Errorf("%s: expected 256 colors but got %d", name, len(cm.Colors))
+			continue
+		}
+		for i, idx := range []int{0, 128, 255} {
+			if c := cm.Colors[idx]; c != w[i] {
+				t.Errorf("%s: expected %v at %d but got %v", name, w[i], idx, c)
+			}
+		}
+	}
+	if cm := stdMaps["Set1"]; !cm.Indexed || cm.Kind != Qualitative {
+		t.Errorf("expected Set1 to be an indexed qualitative map")
+	}
+	if cm := stdMaps["RdBu"]; cm.Kind != Diverging || !cm.CVDSafe {
+		t.Errorf("expected RdBu to be a colorblind safe diverging map")
+	}
+	for _, name := range []string{"Dark2", "Paired", "Set2"} {
+		if stdMaps[name].CVDSafe {
+			t.Errorf("expected %s not to be colorblind safe with all of its classes", name)
+		}
+	}
+}
+
+func absDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func TestAvailMapsByKind(t *testing.T) {
+	byKind := AvailMapsByKind()
+	n := 0
+	for kind, names := range byKind {
+		n += len(names)
+		for _, name := range names {
+			cm, _ := Get(name)
+			if cm.Kind != kind {
+				t.Errorf("%s: expected kind %v but got %v", name, kind, cm.Kind)
+			}
+		}
+	}
+	if all := AvailMapsList(); n != len(all) {
+		t.Errorf("expected %d maps across all kinds but got %d", len(all), n)
+	}
+	if !slices.Contains(AvailMapsOfKind(Cyclic), "LightDarkLight") {
+		t.Errorf("expected LightDarkLight to be cyclic")
+	}
+	if name := stdMaps["LightDarkLight"].Name; name != "LightDarkLight" {
+		t.Errorf("expected LightDarkLight name but got %q", name)
+	}
+}
@@ -0,0 +1,240 @@
+// Code generated by "goki generate ./..."; DO NOT EDIT.
+
+package colormap
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"goki.dev/enums"
+)
+
+var _KindsValues = []Kinds{0, 1, 2, 3}
+
+// KindsN is the highest valid value
+// for type Kinds, plus one.
+const KindsN Kinds = 4
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the enumgen command to generate them again.
+func _KindsNoOp() {
+	var x [1]struct{}
+	_ = x[Sequential-(0)]
+	_ = x[Diverging-(1)]
+	_ = x[Cyclic-(2)]
+	_ = x[Qualitative-(3)]
+}
+
+var _KindsNameToValueMap = map[string]Kinds{
+	`Sequential`:  0,
+	`sequential`:  0,
+	`Diverging`:   1,
+	`diverging`:   1,
+	`Cyclic`:      2,
+	`cyclic`:      2,
+	`Qualitative`: 3,
+	`qualitative`: 3,
+}
+
+var _KindsDescMap = map[Kinds]string{
+	0: `Sequential maps are for ordered data that progresses from low to high, typically with a monotonic change in lightness.`,
+	1: `Diverging maps are for data with a meaningful midpoint (such as zero), with two contrasting hues diverging from a neutral center.`,
+	2: `Cyclic maps are for data that wraps around, such as angles or phases, with the same color at both ends.`,
+	3: `Qualitative maps are for categorical data without an inherent order, and they are typically used in [Map.Indexed] mode.`,
+}
+
+var _KindsMap = map[Kinds]string{
+	0: `Sequential`,
+	1: `Diverging`,
+	2: `Cyclic`,
+	3: `Qualitative`,
+}
+
+// String returns the string representation
+// of this Kinds value.
+func (i Kinds) String() string {
+	if str, ok := _KindsMap[i]; ok {
+		return str
+	}
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// SetString sets the Kinds value from its
+// string representation, and returns an
+// error if the string is invalid.
+func (i *Kinds) SetString(s string) error {
+	if val, ok := _KindsNameToValueMap[s]; ok {
+		*i = val
+		return nil
+	}
+	if val, ok := _KindsNameToValueMap[strings.ToLower(s)]; ok {
+		*i = val
+		return nil
+	}
+	return errors.New(s + " is not a valid value for type Kinds")
+}
+
+// Int64 returns the Kinds value as an int64.
+func (i Kinds) Int64() int64 {
+	return int64(i)
+}
+
+// SetInt64 sets the Kinds value from an int64.
+func (i *Kinds) SetInt64(in int64) {
+	*i = Kinds(in)
+}
+
+// Desc returns the description of the Kinds value.
+func (i Kinds) Desc() string {
+	if str, ok := _KindsDescMap[i]; ok {
+		return str
+	}
+	return i.String()
+}
+
+// KindsValues returns all possible values
+// for the type Kinds.
+func KindsValues() []Kinds {
+	return _KindsValues
+}
+
+// Values returns all possible values
+// for the type Kinds.
+func (i Kinds) Values() []enums.Enum {
+	res := make([]enums.Enum, len(_KindsValues))
+	for i, d := range _KindsValues {
+		res[i] = d
+	}
+	return res
+}
+
+// IsValid returns whether the value is a
+// valid option for type Kinds.
+func (i Kinds) IsValid() bool {
+	_, ok := _KindsMap[i]
+	return ok
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Kinds) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Kinds) UnmarshalText(text []byte) error {
+	if err := i.SetString(string(text)); err != nil {
+		log.Println(err)
+	}
+	return nil
+}
+
+var _SpacesValues = []Spaces{0, 1}
+
+// SpacesN is the highest valid value
+// for type Spaces, plus one.
+const SpacesN Spaces = 2
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the enumgen command to generate them again.
+func _SpacesNoOp() {
+	var x [1]struct{}
+	_ = x[CAM16UCS-(0)]
+	_ = x[OKLab-(1)]
+}
+
+var _SpacesNameToValueMap = map[string]Spaces{
+	`CAM16UCS`: 0,
+	`cam16ucs`: 0,
+	`OKLab`:    1,
+	`oklab`:    1,
+}
+
+var _SpacesDescMap = map[Spaces]string{
+	0: `CAM16UCS is the CAM16-UCS colorspace, in which lightness is in the range 0-100 and a difference of about 1 is barely noticeable.`,
+	1: `OKLab is the OKLab colorspace (see [oklab.OKLab]). Its values are scaled by 100 so that they are on a similar scale as [CAM16UCS], with lightness in the range 0-100 and a difference of about 2 being barely noticeable.`,
+}
+
+var _SpacesMap = map[Spaces]string{
+	0: `CAM16UCS`,
+	1: `OKLab`,
+}
+
+// String returns the string representation
+// of this Spaces value.
+func (i Spaces) String() string {
+	if str, ok := _SpacesMap[i]; ok {
+		return str
+	}
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// SetString sets the Spaces value from its
+// string representation, and returns an
+// error if the string is invalid.
+func (i *Spaces) SetString(s string) error {
+	if val, ok := _SpacesNameToValueMap[s]; ok {
+		*i = val
+		return nil
+	}
+	if val, ok := _SpacesNameToValueMap[strings.ToLower(s)]; ok {
+		*i = val
+		return nil
+	}
+	return errors.New(s + " is not a valid value for type Spaces")
+}
+
+// Int64 returns the Spaces value as an int64.
+func (i Spaces) Int64() int64 {
+	return int64(i)
+}
+
+// SetInt64 sets the Spaces value from an int64.
+func (i *Spaces) SetInt64(in int64) {
+	*i = Spaces(in)
+}
+
+// Desc returns the description of the Spaces value.
+func (i Spaces) Desc() string {
+	if str, ok := _SpacesDescMap[i]; ok {
+		return str
+	}
+	return i.String()
+}
+
+// SpacesValues returns all possible values
+// for the type Spaces.
+func SpacesValues() []Spaces {
+	return _SpacesValues
+}
+
+// Values returns all possible values
+// for the type Spaces.
+func (i Spaces) Values() []enums.Enum {
+	res := make([]enums.Enum, len(_SpacesValues))
+	for i, d := range _SpacesValues {
+		res[i] = d
+	}
+	return res
+}
+
+// IsValid returns whether the value is a
+// valid option for type Spaces.
+func (i Spaces) IsValid() bool {
+	_, ok := _SpacesMap[i]
+	return ok
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Spaces) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Spaces) UnmarshalText(text []byte) error {
+	if err := i.SetString(string(text)); err != nil {
+		log.Println(err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+
+	"goki.dev/cam/hct"
+	"goki.dev/colors"
+	"goki.dev/colors/oklab"
+	"goki.dev/mat32/v2"
+)
+
+// generateN is the number of colors in generated color maps, which is
+// enough for them to be accurately blended in RGB, and odd so that
+// diverging maps have a color at their midpoint.
+const generateN = 65
+
+// NewSequential returns a new [Sequential] color map with the given name
+// that is a lightness ramp of the hue of the given key color, from a very light
+// tint to a dark shade, with the chroma of the key color in the middle of the
+// ramp. The lightness changes in perceptually even steps in the given
+// colorspace, which is HCT for [CAM16UCS] and OKLCH for [OKLab].
+func NewSequential(name string, key color.Color, sp Spaces) *Map {
+	_, c, h := keyLCH(key, sp)
+	cm := newGenerated(name, Sequential)
+	for i := range cm.Colors {
+		f := float32(i) / (generateN - 1)
+		// chroma is highest in the middle of the ramp,
+		// where the most chroma is available
+		cf := 0.25 + 0.75*mat32.Sin(mat32.Pi*f)
+		cm.Colors[i] = lchColor(sp, 97-87*f, c*cf, h)
+	}
+	return cm
+}
+
+// NewDiverging returns a new [Diverging] color map with the given name that
+// goes from a dark shade of the hue of the given low key color through a light
+// neutral gray at the midpoint to a dark shade of the hue of the given high
+// key color, with the chroma of the key colors at the ends. The lightness is
+// symmetrical around the midpoint and changes in perceptually even steps in
+// the given colorspace, which is HCT for [CAM16UCS] and OKLCH for [OKLab].
+func NewDiverging(name string, low, high color.Color, sp Spaces) *Map {
+	_, lc, lh := keyLCH(low, sp)
+	_, hc, hh := keyLCH(high, sp)
+	cm := newGenerated(name, Diverging)
+	for i := range cm.Colors {
+		f := 2*float32(i)/(generateN-1) - 1 // -1 to 1
+		c, h := lc, lh
+		if f > 0 {
+			c, h = hc, hh
+		}
+		a := mat32.Abs(f)
+		cm.Colors[i] = lchColor(sp, 95-60*a, c*a, h)
+	}
+	return cm
+}
+
+// NewCyclic returns a new [Cyclic] color map with the given name that goes
+// around the full circle of hues, starting and ending at the hue of the given
+// key color, with the lightness of the key color. The chroma is the chroma of
+// the key color, reduced to the highest chroma that is available for all
+// hues at that lightness, so that the map is perceptually even in the given
+// colorspace, which is HCT for [CAM16UCS] and OKLCH for [OKLab].
+func NewCyclic(name string, key color.Color, sp Spaces) *Map {
+	l, c, h := keyLCH(key, sp)
+	// find the highest chroma available for all hues
+	for hi := 0; hi < 36; hi++ {
+		c = min(c, maxChroma(sp, l, c, float32(hi*10)))
+	}
+	cm := newGenerated(name, Cyclic)
+	for i := range cm.Colors {
+		f := float32(i) / (generateN - 1)
+		cm.Colors[i] = lchColor(sp, l, c, h+360*f)
+	}
+	cm.Colors[generateN-1] = cm.Colors[0]
+	return cm
+}
+
+// newGenerated returns a new generated color map with the given name and kind.
+func newGenerated(name string, kind Kinds) *Map {
+	return &Map{
+		Name:    name,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Blend:   colors.RGB,
+		Kind:    kind,
+		Colors:  make([]color.RGBA, generateN),
+	}
+}
+
+// keyLCH returns the lightness (0-100), chroma, and hue of the given
+// key color in the HCT colorspace for [CAM16UCS] and the OKLCH
+// colorspace for [OKLab].
+func keyLCH(key color.Color, sp Spaces) (l, c, h float32) {
+	if sp == OKLab {
+		l, c, h = oklab.FromColor(key).LCH()
+		return 100 * l, c, h
+	}
+	k := hct.FromColor(key)
+	return k.Tone, k.Chroma, k.Hue
+}
+
+// lchColor returns the color with the given lightness (0-100), chroma, and hue
+// in the HCT colorspace for [CAM16UCS] and the OKLCH colorspace for [OKLab],
+// reducing the chroma as needed for the color to be in the sRGB gamut.
+func lchColor(sp Spaces, l, c, h float32) color.RGBA {
+	if sp == OKLab {
+		return oklab.FromLCH(l/100, maxChroma(sp, l, c, h), h).AsRGBA()
+	}
+	return hct.New(h, c, l).AsRGBA()
+}
+
+// maxChroma returns the highest chroma up to the given chroma that is in the
+// sRGB gamut for the given lightness (0-100) and hue in the HCT colorspace
+// for [CAM16UCS] and the OKLCH colorspace for [OKLab].
+func maxChroma(sp Spaces, l, c, h float32) float32 {
+	if sp != OKLab {
+		return min(c, hct.New(h, c, l).Chroma)
+	}
+	if oklab.FromLCH(l/100, c, h).InGamut() {
+		return c
+	}
+	lo, hi := float32(0), c
+	for i := 0; i < 16; i++ {
+		mid := (lo + hi) / 2
+		if oklab.FromLCH(l/100, mid, h).InGamut() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return lo
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"testing"
+
+	"goki.dev/colors"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, sp := range SpacesValues() {
+		s := NewSequential("Brand", colors.FromRGB(0, 90, 180), sp)
+		a := s.Analyze(64, sp)
+		if s.Kind != Sequential || !a.Monotonic {
+			t.Errorf("%v: expected monotonic sequential map", sp)
+		}
+		if a.Lightness[0] < 90 || a.Lightness[63] > 20 {
+			t.Errorf("%v: expected lightness from light to dark but got %g to %g", sp, a.Lightness[0], a.Lightness[63])
+		}
+
+		d := NewDiverging("BrandDiverging", colors.FromRGB(0, 90, 180), colors.FromRGB(200, 40, 20), sp)
+		dl := d.LightnessProfile(65, sp)
+		if d.Kind != Diverging || dl[32] < dl[0] || dl[32] < dl[64] {
+			t.Errorf("%v: expected diverging map with light center but got %v", sp, dl)
+		}
+		if mid := d.Map(0.5); absDiff(mid.R, mid.G) > 2 || absDiff(mid.G, mid.B) > 2 {
+			t.Errorf("%v: expected neutral center but got %v", sp, mid)
+		}
+
+		c := NewCyclic("BrandCyclic", colors.FromRGB(0, 90, 180), sp)
+		ca := c.Analyze(64, sp)
+		if c.Kind != Cyclic || c.Colors[0] != c.Colors[len(c.Colors)-1] {
+			t.Errorf("%v: expected cyclic map with equal ends", sp)
+		}
+		if ca.Uniformity > 0.5 {
+			t.Errorf("%v: expected roughly even cyclic map but got uniformity %g", sp, ca.Uniformity)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"strconv"
+	"strings"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+// ReadGGR reads a color map from the given GIMP gradient (.ggr) file. Each
+// segment of the gradient results in colors at its left and right endpoints,
+// and at its midpoint if it is not centered, stored in [Map.Positions].
+// Segments are always blended linearly in RGB, so the curved, sinusoidal,
+// and spherical blending functions and the HSV coloring types of GIMP
+// gradients are approximated.
+func ReadGGR(r io.Reader) (*Map, error) {
+	sc := bufio.NewScanner(r)
+	line := 0
+	next := func() (string, bool) {
+		for sc.Scan() {
+			line++
+			if s := strings.TrimSpace(sc.Text()); s != "" {
+				return s, true
+			}
+		}
+		return "", false
+	}
+	s, ok := next()
+	if !ok || s != "GIMP Gradient" {
+		return nil, fmt.Errorf("colormap.ReadGGR: missing GIMP Gradient header")
+	}
+	cm := &Map{
+		NoColor: colors.FromRGB(200, 200, 200),
+		Blend:   colors.RGB,
+	}
+	s, ok = next()
+	if name, has := strings.CutPrefix(s, "Name:"); has {
+		cm.Name = strings.TrimSpace(name)
+		s, ok = next()
+	}
+	if !ok {
+		return nil, fmt.Errorf("colormap.ReadGGR: missing number of segments")
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, fmt.Errorf("colormap.ReadGGR: invalid number of segments %q: %w", s, err)
+	}
+	add := func(pos float32, c color.RGBA) {
+		nc := len(cm.Colors)
+		if nc > 0 && cm.Positions[nc-1] == pos && cm.Colors[nc-1] == c {
+			return
+		}
+		cm.Colors = append(cm.Colors, c)
+		cm.Positions = append(cm.Positions, pos)
+	}
+	for i := 0; i < n; i++ {
+		s, ok = next()
+		if !ok {
+			return nil, fmt.Errorf("colormap.ReadGGR: expected %d segments but got %d", n, i)
+		}
+		fs := strings.Fields(s)
+		if len(fs) < 11 {
+			return nil, fmt.Errorf("colormap.ReadGGR: line %d: expected at least 11 values but got %d", line, len(fs))
+		}
+		vs := make([]float32, 11)
+		for j := range vs {
+			v, err := strconv.ParseFloat(fs[j], 32)
+			if err != nil {
+				return nil, fmt.Errorf("colormap.ReadGGR: line %d: %w", line, err)
+			}
+			vs[j] = float32(v)
+		}
+		left, mid, right := vs[0], vs[1], vs[2]
+		lc := fromFloats(vs[3], vs[4], vs[5], vs[6])
+		rc := fromFloats(vs[7], vs[8], vs[9], vs[10])
+		add(left, lc)
+		if mat32.Abs(mid-(left+right)/2) > 1e-4 {
+			add(mid, fromFloats((vs[3]+vs[7])/2, (vs[4]+vs[8])/2, (vs[5]+vs[9])/2, (vs[6]+vs[10])/2))
+		}
+		add(right, rc)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(cm.Colors) == 0 {
+		return nil, fmt.Errorf("colormap.ReadGGR: no segments found")
+	}
+	return cm, nil
+}
+
+// WriteGGR writes the given color map to the given writer as a GIMP
+// gradient (.ggr) file, with one linear RGB segment between each
+// pair of adjacent colors.
+func WriteGGR(w io.Writer, cm *Map) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "GIMP Gradient\nName: %s\n", cm.Name)
+	ps := cm.positions()
+	nc := len(cm.Colors)
+	segment := func(left, right float32, lc, rc color.RGBA) {
+		lr, lg, lb, la := colors.ToFloat32(lc)
+		rr, rg, rb, ra := colors.ToFloat32(rc)
+		fmt.Fprintf(bw, "%f %f %f %f %f %f %f %f %f %f %f 0 0\n", left, (left+right)/2, right, lr, lg, lb, la, rr, rg, rb, ra)
+	}
+	switch nc {
+	case 0:
+		fmt.Fprintln(bw, 0)
+	case 1:
+		fmt.Fprintln(bw, 1)
+		segment(0, 1, cm.Colors[0], cm.Colors[0])
+	default:
+		fmt.Fprintln(bw, nc-1)
+		for i := 0; i < nc-1; i++ {
+			segment(ps[i], ps[i+1], cm.Colors[i], cm.Colors[i+1])
+		}
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+// Open reads the color maps in the given file and registers them in the
+// [DefaultRegistry] (see [Register]).
+// The format of the file is determined by its extension:
+//   - .xml: ParaView XML (see [ReadParaViewXML])
+//   - .json: ParaView JSON (see [ReadParaViewJSON]) or a simple JSON list (see [ReadJSON])
+//   - .ggr: GIMP gradient (see [ReadGGR])
+//   - .csv: simple CSV list (see [ReadCSV])
+//   - .py, .txt: matplotlib segmented data (see [ParseSegmented])
+//
+// For formats that do not contain the name of the color map, the
+// name of the file without its extension is used.
+func Open(filename string) ([]*Map, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	ext := strings.ToLower(filepath.Ext(filename))
+	name := strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+	var cms []*Map
+	var cm *Map
+	switch ext {
+	case ".xml":
+		cms, err = ReadParaViewXML(bytes.NewReader(b))
+	case ".json":
+		if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[{")) || bytes.Contains(b, []byte("RGBPoints")) {
+			cms, err = ReadParaViewJSON(bytes.NewReader(b))
+		} else {
+			cm, err = ReadJSON(bytes.NewReader(b))
+		}
+	case ".ggr":
+		cm, err = ReadGGR(bytes.NewReader(b))
+	case ".csv":
+		cm, err = ReadCSV(bytes.NewReader(b), name)
+	case ".py", ".txt":
+		cm, err = ParseSegmented(name, string(b))
+	default:
+		return nil, fmt.Errorf("colormap.Open: unsupported file extension %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("colormap.Open: error reading %q: %w", filename, err)
+	}
+	if cm != nil {
+		if cm.Name == "" {
+			cm.Name = name
+		}
+		cms = append(cms, cm)
+	}
+	Register(cms...)
+	return cms, nil
+}
+
+// ReadCSV reads a color map with the given name from the given simple CSV list
+// of colors, with one color per row. Each row is either a single color string
+// (see [colors.FromString]), such as a hex color, or three or four red, green,
+// blue, and optional alpha components. The components are interpreted as
+// 0-1 values if all of them are at most 1, and 0-255 values otherwise.
+// A header row is skipped if the first row is not a valid color.
+func ReadCSV(r io.Reader, name string) (*Map, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	recs, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	cm := &Map{
+		Name:    name,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Blend:   colors.RGB,
+	}
+	var comps [][]float32
+	unit := true
+	for i, rec := range recs {
+		switch len(rec) {
+		case 1:
+			c, err := colors.FromString(rec[0])
+			if err == nil {
+				cm.Colors = append(cm.Colors, c)
+				continue
+			}
+		case 3, 4:
+			cs := make([]float32, 4)
+			cs[3] = -1 // alpha not specified
+			var err error
+			for j, f := range rec {
+				var v float64
+				v, err = strconv.ParseFloat(f, 32)
+				if err != nil {
+					break
+				}
+				cs[j] = float32(v)
+				if v > 1 {
+					unit = false
+				}
+			}
+			if err == nil {
+				comps = append(comps, cs)
+				continue
+			}
+		}
+		if i > 0 {
+			return nil, fmt.Errorf("colormap.ReadCSV: invalid color in row %d: %v", i+1, rec)
+		}
+	}
+	if len(cm.Colors) > 0 && len(comps) > 0 {
+		return nil, fmt.Errorf("colormap.ReadCSV: rows must either all be color strings or all be components")
+	}
+	for _, cs := range comps {
+		scale := float32(1)
+		if !unit {
+			scale = 255
+		}
+		if cs[3] < 0 {
+			cs[3] = scale
+		}
+		cm.Colors = append(cm.Colors, fromFloats(cs[0]/scale, cs[1]/scale, cs[2]/scale, cs[3]/scale))
+	}
+	if len(cm.Colors) == 0 {
+		return nil, fmt.Errorf("colormap.ReadCSV: no colors found")
+	}
+	return cm, nil
+}
+
+// WriteCSV writes the colors of the given color map to the given writer as a
+// simple CSV list of hex colors, with one color per row. The positions of the
+// colors are not written; see [WriteJSON] for that.
+func WriteCSV(w io.Writer, cm *Map) error {
+	for _, c := range cm.Colors {
+		if _, err := fmt.Fprintln(w, colors.AsHex(c)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonMap is the simple JSON representation of a color map
+// used by [ReadJSON] and [WriteJSON].
+type jsonMap struct {
+	Name      string    `json:"name,omitempty"`
+	Kind      string    `json:"kind,omitempty"`
+	Indexed   bool      `json:"indexed,omitempty"`
+	Colors    []string  `json:"colors"`
+	Positions []float32 `json:"positions,omitempty"`
+}
+
+// ReadJSON reads a color map from the given simple JSON list, which is either
+// an array of color strings (see [colors.FromString]), such as hex colors, or an
+// object with a "colors" array of color strings and optional "name", "kind",
+// "indexed", and "positions" fields (see [Map.Kind], [Map.Indexed], and
+// [Map.Positions]), as written by [WriteJSON]. It returns an error if there
+// are positions that are not sorted values from 0 to 1, one for each color.
+func ReadJSON(r io.Reader) (*Map, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var jm jsonMap
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		err = json.Unmarshal(b, &jm.Colors)
+	} else {
+		err = json.Unmarshal(b, &jm)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cm := &Map{
+		Name:      jm.Name,
+		Indexed:   jm.Indexed,
+		NoColor:   colors.FromRGB(200, 200, 200),
+		Blend:     colors.RGB,
+		Positions: jm.Positions,
+	}
+	if jm.Kind != "" {
+		if err := cm.Kind.SetString(jm.Kind); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range jm.Colors {
+		c, err := colors.FromString(s)
+		if err != nil {
+			return nil, err
+		}
+		cm.Colors = append(cm.Colors, c)
+	}
+	if np := len(cm.Positions); np > 0 {
+		if np != len(cm.Colors) {
+			return nil, fmt.Errorf("colormap.ReadJSON: got %d positions for %d colors", np, len(cm.Colors))
+		}
+		for i, p := range cm.Positions {
+			if p < 0 || p > 1 || (i > 0 && p < cm.Positions[i-1]) {
+				return nil, fmt.Errorf("colormap.ReadJSON: positions must be sorted values from 0 to 1, but got %v", cm.Positions)
+			}
+		}
+	}
+	return cm, nil
+}
+
+// WriteJSON writes the given color map to the given writer as a simple JSON
+// object with its name, kind, indexed flag, hex colors, and positions,
+// which can be read with [ReadJSON].
+func WriteJSON(w io.Writer, cm *Map) error {
+	jm := jsonMap{
+		Name:      cm.Name,
+		Kind:      cm.Kind.String(),
+		Indexed:   cm.Indexed,
+		Colors:    make([]string, len(cm.Colors)),
+		Positions: cm.Positions,
+	}
+	for i, c := range cm.Colors {
+		jm.Colors[i] = colors.AsHex(c)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(jm)
+}
+
+// fromFloats returns the color with the given normalized
+// non-alpha-premultiplied channel values (see [colors.FromNRGBAF32]),
+// which are clamped to the range 0-1, as the values read from
+// files can be slightly out of range due to rounding.
+func fromFloats(r, g, b, a float32) color.RGBA {
+	return colors.FromNRGBAF32(mat32.Clamp(r, 0, 1), mat32.Clamp(g, 0, 1), mat32.Clamp(b, 0, 1), mat32.Clamp(a, 0, 1))
+}
+
+// positions returns the positions of the colors of the given color map,
+// which are [Map.Positions] if they are specified, and evenly spaced
+// from 0 to 1 otherwise.
+func (cm *Map) positions() []float32 {
+	nc := len(cm.Colors)
+	if len(cm.Positions) == nc {
+		return cm.Positions
+	}
+	ps := make([]float32, nc)
+	for i := range ps {
+		if nc > 1 {
+			ps[i] = float32(i) / float32(nc-1)
+		}
+	}
+	return ps
+}
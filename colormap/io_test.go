@@ -0,0 +1,214 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"goki.dev/colors"
+)
+
+// testIOMap is a color map with non-uniform positions and a sharp transition.
+var testIOMap = &Map{
+	Name:      "TestIO",
+	Blend:     colors.RGB,
+	NoColor:   colors.FromRGB(200, 200, 200),
+	Colors:    []color.RGBA{colors.FromRGB(0, 0, 255), colors.FromRGB(255, 255, 255), colors.FromRGB(255, 0, 0), colors.FromRGB(51, 0, 0)},
+	Positions: []float32{0, 0.25, 0.25, 1},
+}
+
+// expectSameMap checks that the given color maps have the same
+// colors and positions (see [Map.positions]).
+func expectSameMap(t *testing.T, want, have *Map) {
+	t.Helper()
+	if !slices.Equal(want.Colors, have.Colors) {
+		t.Errorf("expected colors %v but got %v", want.Colors, have.Colors)
+	}
+	if !slices.Equal(want.positions(), have.positions()) {
+		t.Errorf("expected positions %v but got %v", want.positions(), have.positions())
+	}
+}
+
+func TestParaView(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteParaViewXML(&b, testIOMap); err != nil {
+		t.Fatal(err)
+	}
+	cms, err := ReadParaViewXML(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cms) != 1 || cms[0].Name != "TestIO" || cms[0].NoColor != testIOMap.NoColor {
+		t.Fatalf("expected one map named TestIO but got %v", cms)
+	}
+	expectSameMap(t, testIOMap, cms[0])
+
+	b.Reset()
+	if err := WriteParaViewJSON(&b, testIOMap); err != nil {
+		t.Fatal(err)
+	}
+	cms, err = ReadParaViewJSON(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectSameMap(t, testIOMap, cms[0])
+
+	// a single map with values in data units instead of 0-1
+	xml := `<ColorMap name="Cool to Warm" space="Diverging">
+  <Point x="-10" o="0" r="0.231373" g="0.298039" b="0.752941"/>
+  <Point x="0" o="0.5" r="0.865003" g="0.865003" b="0.865003"/>
+  <Point x="30" o="1" r="0.705882" g="0.0156863" b="0.14902"/>
+  <NaN r="1" g="1" b="0"/>
+</ColorMap>`
+	cms, err = ReadParaViewXML(strings.NewReader(xml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cm := cms[0]
+	if cm.Name != "Cool to Warm" || cm.Blend != colors.HCT || cm.NoColor != colors.FromRGB(255, 255, 0) {
+		t.Errorf("unexpected map properties %q %v %v", cm.Name, cm.Blend, cm.NoColor)
+	}
+	if !slices.Equal(cm.Positions, []float32{0, 0.25, 1}) || cm.Colors[0] != colors.FromRGB(59, 76, 192) {
+		t.Errorf("unexpected map points %v %v", cm.Positions, cm.Colors)
+	}
+}
+
+func TestGGR(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteGGR(&b, testIOMap); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := ReadGGR(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Name != "TestIO" {
+		t.Errorf("expected name TestIO but got %q", cm.Name)
+	}
+	expectSameMap(t, testIOMap, cm)
+
+	// off-center midpoint and alpha
+	ggr := `GIMP Gradient
+Name: Test
+1
+0.000000 0.250000 1.000000 0.000000 0.000000 0.000000 1.000000 1.000000 1.000000 1.000000 0.000000 0 0
+`
+	cm, err = ReadGGR(strings.NewReader(ggr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []color.RGBA{colors.Black, colors.FromNRGBA(128, 128, 128, 128), {}}
+	if !slices.Equal(cm.Colors, want) || !slices.Equal(cm.Positions, []float32{0, 0.25, 1}) {
+		t.Errorf("expected %v at [0 0.25 1] but got %v at %v", want, cm.Colors, cm.Positions)
+	}
+}
+
+func TestCSVJSON(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteCSV(&b, testIOMap); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := ReadCSV(&b, "TestIO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(cm.Colors, testIOMap.Colors) {
+		t.Errorf("expected colors %v but got %v", testIOMap.Colors, cm.Colors)
+	}
+
+	cm, err = ReadCSV(strings.NewReader("r,g,b\n0,0,1\n1,0.5,0\n"), "Floats")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []color.RGBA{colors.FromRGB(0, 0, 255), colors.FromRGB(255, 128, 0)}
+	if !slices.Equal(cm.Colors, want) {
+		t.Errorf("expected colors %v but got %v", want, cm.Colors)
+	}
+
+	b.Reset()
+	d := *testIOMap
+	d.Kind = Diverging
+	if err := WriteJSON(&b, &d); err != nil {
+		t.Fatal(err)
+	}
+	cm, err = ReadJSON(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cm.Name != "TestIO" || cm.Kind != Diverging {
+		t.Errorf("expected diverging map named TestIO but got %q %v", cm.Name, cm.Kind)
+	}
+	expectSameMap(t, testIOMap, cm)
+
+	cm, err = ReadJSON(strings.NewReader(`["#000", "white"]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(cm.Colors, []color.RGBA{colors.Black, colors.White}) {
+		t.Errorf("expected black and white but got %v", cm.Colors)
+	}
+	for _, js := range []string{
+		`{"colors": ["#000", "#fff"], "positions": [0]}`,
+		`{"colors": ["#000", "#888", "#fff"], "positions": [0, 0.8, 0.5]}`,
+		`{"colors": ["#000", "#fff"], "positions": [0, 2]}`,
+	} {
+		if _, err := ReadJSON(strings.NewReader(js)); err == nil {
+			t.Errorf("expected error for invalid positions in %s", js)
+		}
+	}
+}
+
+func TestSegmentedSource(t *testing.T) {
+	var b bytes.Buffer
+	if err := WriteSegmented(&b, testIOMap); err != nil {
+		t.Fatal(err)
+	}
+	cm, err := ParseSegmented("TestIO", b.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectSameMap(t, testIOMap, cm)
+
+	// from the matplotlib source code of the "gray" map
+	src := `_binary_data = {
+    'red':    ((0., 1., 1.), (1., 0., 0.)),
+    'green':  ((0., 1., 1.), (1., 0., 0.)),
+    'blue':   ((0., 1., 1.), (1., 0., 0.))
+    }`
+	cm, err = ParseSegmented("binary", src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(cm.Colors, []color.RGBA{colors.White, colors.Black}) {
+		t.Errorf("expected white and black but got %v", cm.Colors)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "TestOpen.ggr")
+	var b bytes.Buffer
+	if err := WriteGGR(&b, testIOMap); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fn, b.Bytes(), 0666); err != nil {
+		t.Fatal(err)
+	}
+	cms, err := Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer Remove("TestIO")
+	cm, ok := Get("TestIO")
+	if len(cms) != 1 || !ok {
+		t.Fatalf("expected loaded map to be registered")
+	}
+	expectSameMap(t, cms[0], cm)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image"
+	"image/color"
+	"runtime"
+	"sync"
+
+	"goki.dev/mat32/v2"
+)
+
+// DefaultLUTSize is the recommended number of colors in the lookup table of
+// a color map (see [Map.UseLUT]), which is enough for the difference between
+// adjacent colors to be imperceptible for typical color maps.
+const DefaultLUTSize = 1024
+
+// lookupTable is a precomputed lookup table of the colors of a color map
+// evenly spaced from 0 to 1 (see [Map.UseLUT]).
+type lookupTable struct {
+	// the colors of the table
+	colors []color.RGBA
+
+	// whether the range of values of each entry of the table contains a
+	// sharp transition of the color map, in which case the colors are
+	// blended instead of using the entry; nil if there are none
+	sharp []bool
+}
+
+// UseLUT makes the color map use a precomputed lookup table of the given
+// number of colors evenly spaced from 0 to 1, such that [Map.Map] returns the
+// nearest color in the table instead of blending colors, which is much faster,
+// especially for the [colors.HCT] and [colors.CAM16] blend types.
+// [DefaultLUTSize] is typically a good size. A size of less than 2 makes the
+// color map stop using a lookup table. For values near a sharp transition
+// (two colors at the same position in [Map.Positions]), the colors are still
+// blended, so that the transition stays at its exact position instead of
+// moving to the nearest entry of the table. The table is not updated
+// automatically, so UseLUT must be called again after the Colors, Positions,
+// or Blend of the map are changed for the table to reflect the changes, and it
+// must not be called while the map is being used in other goroutines. Copies of
+// the map made with [Map.Clone] do not have the table, but the copies stored in
+// and returned by a [Registry] do.
+func (cm *Map) UseLUT(n int) {
+	if n < 2 || len(cm.Colors) < 2 {
+		cm.lut = nil
+		return
+	}
+	lut := &lookupTable{colors: make([]color.RGBA, n)}
+	for i := range lut.colors {
+		lut.colors[i] = cm.mapBlend(float32(i) / float32(n-1))
+	}
+	if len(cm.Positions) == len(cm.Colors) {
+		for i := 1; i < len(cm.Positions); i++ {
+			if p := cm.Positions[i]; p == cm.Positions[i-1] {
+				if lut.sharp == nil {
+					lut.sharp = make([]bool, n)
+				}
+				// the neighboring entries are also included in case
+				// the transition is on the boundary between entries
+				j := lutIndex(p, n)
+				for k := max(j-1, 0); k <= min(j+1, n-1); k++ {
+					lut.sharp[k] = true
+				}
+			}
+		}
+	}
+	cm.lut = lut
+}
+
+// HasLUT returns whether the color map is using a lookup table (see [Map.UseLUT]).
+func (cm *Map) HasLUT() bool {
+	return cm.lut != nil
+}
+
+// mapLUT returns the color for the given normalized value
+// from the lookup table of the color map.
+func (cm *Map) mapLUT(val float32) color.RGBA {
+	if mat32.IsNaN(val) {
+		return cm.NoColor
+	}
+	i := lutIndex(val, len(cm.lut.colors))
+	if cm.lut.sharp != nil && cm.lut.sharp[i] {
+		return cm.mapBlend(val)
+	}
+	return cm.lut.colors[i]
+}
+
+// lutIndex returns the index of the nearest entry for the given
+// normalized value in a lookup table of the given size.
+func lutIndex(val float32, n int) int {
+	return int(mat32.Clamp(val, 0, 1)*float32(n-1) + 0.5)
+}
+
+// MapImage returns a new image of the given size with the colors for the given
+// normalized values (see [Map.Map]), which are in row-major order, such that the
+// value for pixel (x, y) is vals[y*width+x]. See [Map.MapInto] for more information.
+func (cm *Map) MapImage(vals []float32, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	cm.MapInto(img, vals, 0, 1, width)
+	return img
+}
+
+// MapInto sets the pixels of the given image to the colors for the given
+// normalized values (see [Map.Map]), which are in a strided buffer such as
+// that of a tensor, with the given offset of the first value and strides
+// between values in the x and y directions, such that the value for pixel
+// (x, y) relative to the bounds of the image is vals[offset+y*ystride+x*xstride].
+// For example, a transposed column-major buffer of a w by h image has an
+// xstride of h and a ystride of 1. The buffer must contain all of the values
+// for the image. The rows of the image are mapped in parallel, so it is
+// recommended to use a lookup table (see [Map.UseLUT]) for large images.
+func (cm *Map) MapInto(img *image.RGBA, vals []float32, offset, xstride, ystride int) {
+	mapInto(img, vals, offset, xstride, ystride, cm.Map)
+}
+
+// MapImage returns a new image of the given size with the colors for the given
+// raw values (see [Scale.Map]), which are in row-major order, such that the
+// value for pixel (x, y) is vals[y*width+x]. See [Map.MapInto] for more information.
+func (s *Scale) MapImage(vals []float32, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	s.MapInto(img, vals, 0, 1, width)
+	return img
+}
+
+// MapInto sets the pixels of the given image to the colors for the given raw
+// values (see [Scale.Map]), which are in a strided buffer with the given offset
+// and strides. See [Map.MapInto] for more information.
+func (s *Scale) MapInto(img *image.RGBA, vals []float32, offset, xstride, ystride int) {
+	mapInto(img, vals, offset, xstride, ystride, s.Map)
+}
+
+// minParallelPixels is the minimum number of pixels in an image
+// for [mapInto] to map its rows in parallel.
+const minParallelPixels = 4096
+
+// mapInto sets the pixels of the given image to the colors returned by the
+// given function for the values in the given strided buffer, mapping
+// contiguous blocks of rows in parallel.
+func mapInto(img *image.RGBA, vals []float32, offset, xstride, ystride int, mapFunc func(val float32) color.RGBA) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= 0 || h <= 0 {
+		return
+	}
+	rows := func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			pi := img.PixOffset(b.Min.X, b.Min.Y+y)
+			vi := offset + y*ystride
+			for x := 0; x < w; x++ {
+				c := mapFunc(vals[vi])
+				p := img.Pix[pi : pi+4 : pi+4]
+				p[0], p[1], p[2], p[3] = c.R, c.G, c.B, c.A
+				pi += 4
+				vi += xstride
+			}
+		}
+	}
+	nproc := min(runtime.GOMAXPROCS(0), h)
+	if nproc < 2 || w*h < minParallelPixels {
+		rows(0, h)
+		return
+	}
+	var wg sync.WaitGroup
+	wg.Add(nproc)
+	for i := 0; i < nproc; i++ {
+		go func(i int) {
+			defer wg.Done()
+			rows(i*h/nproc, (i+1)*h/nproc)
+		}(i)
+	}
+	wg.Wait()
+}
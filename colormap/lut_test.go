@@ -0,0 +1,145 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestLUT(t *testing.T) {
+	cm := stdMaps["ColdHot"].Clone()
+	cm.Blend = colors.HCT
+	lm := cm.Clone()
+	lm.UseLUT(DefaultLUTSize)
+	if !lm.HasLUT() || cm.HasLUT() {
+		t.Fatalf("expected only the second map to have a LUT")
+	}
+	if lm.Clone().HasLUT() {
+		t.Errorf("expected clone to not have a LUT")
+	}
+	if rm, _ := NewRegistry(lm).Get("ColdHot"); !rm.HasLUT() {
+		t.Errorf("expected map from registry to have a LUT")
+	}
+	expectMapAt(t, cm, lm, [2]float32{0, 0}, [2]float32{0.1, 0.1}, [2]float32{0.37, 0.37}, [2]float32{0.5, 0.5}, [2]float32{0.92, 0.92}, [2]float32{1, 1}, [2]float32{-1, -1}, [2]float32{2, 2})
+	if c := lm.Map(mat32.NaN()); c != lm.NoColor {
+		t.Errorf("expected NoColor for NaN but got %v", c)
+	}
+	lm.UseLUT(0)
+	if lm.HasLUT() {
+		t.Errorf("expected no LUT after UseLUT(0)")
+	}
+}
+
+func TestLUTSharp(t *testing.T) {
+	cm := &Map{
+		Name:      "Sharp",
+		Blend:     colors.RGB,
+		Colors:    []color.RGBA{colors.Black, colors.Red, colors.Blue, colors.White},
+		Positions: []float32{0, 0.3, 0.3, 1},
+	}
+	lm := cm.Clone()
+	lm.UseLUT(16)
+	if c := lm.Map(0.299); c != cm.Map(0.299) || c.B != 0 {
+		t.Errorf("expected red side of the sharp transition at 0.299 but got %v", c)
+	}
+	if c := lm.Map(0.3); c != colors.Blue {
+		t.Errorf("expected blue at the sharp transition at 0.3 but got %v", c)
+	}
+	expectMapAt(t, cm, lm, [2]float32{0, 0}, [2]float32{0.29, 0.29}, [2]float32{0.31, 0.31}, [2]float32{1, 1})
+}
+
+func TestMapImage(t *testing.T) {
+	cm := stdMaps["Viridis"]
+	w, h := 100, 80
+	vals := make([]float32, w*h)
+	for i := range vals {
+		vals[i] = float32(i) / float32(len(vals)-1)
+	}
+	vals[5] = mat32.NaN()
+	img := cm.MapImage(vals, w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if want, have := cm.Map(vals[y*w+x]), img.RGBAAt(x, y); want != have {
+				t.Fatalf("expected %v at (%d, %d) but got %v", want, x, y, have)
+			}
+		}
+	}
+
+	// transposed buffer into a sub-image
+	dst := image.NewRGBA(image.Rect(0, 0, h+10, w+10))
+	sub := dst.SubImage(image.Rect(5, 5, h+5, w+5)).(*image.RGBA)
+	cm.MapInto(sub, vals, 0, w, 1)
+	for y := 0; y < w; y++ {
+		for x := 0; x < h; x++ {
+			if want, have := cm.Map(vals[x*w+y]), dst.RGBAAt(x+5, y+5); want != have {
+				t.Fatalf("expected %v at (%d, %d) but got %v", want, x, y, have)
+			}
+		}
+	}
+	if c := dst.RGBAAt(2, 2); c.A != 0 {
+		t.Errorf("expected pixels outside of sub-image to be unset but got %v", c)
+	}
+
+	s := NewLinearScale(cm, 0, 10)
+	for i := range vals {
+		vals[i] *= 10
+	}
+	img = s.MapImage(vals, w, h)
+	if want, have := s.Map(vals[w*h/2]), img.RGBAAt(0, h/2); want != have {
+		t.Errorf("expected %v but got %v", want, have)
+	}
+}
+
+// benchVals returns a grid of values for benchmarking of the given size.
+func benchVals(n int) []float32 {
+	vals := make([]float32, n*n)
+	for i := range vals {
+		vals[i] = float32(i%n) / float32(n-1)
+	}
+	return vals
+}
+
+func BenchmarkMapHCT(b *testing.B) {
+	cm := stdMaps["ColdHot"].Clone()
+	cm.Blend = colors.HCT
+	vals := benchVals(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range vals {
+			cm.Map(v)
+		}
+	}
+}
+
+func BenchmarkMapLUT(b *testing.B) {
+	cm := stdMaps["ColdHot"].Clone()
+	cm.Blend = colors.HCT
+	cm.UseLUT(DefaultLUTSize)
+	vals := benchVals(100)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, v := range vals {
+			cm.Map(v)
+		}
+	}
+}
+
+func BenchmarkMapImageLUT(b *testing.B) {
+	cm := stdMaps["ColdHot"].Clone()
+	cm.Blend = colors.HCT
+	cm.UseLUT(DefaultLUTSize)
+	n := 1000
+	vals := benchVals(n)
+	img := image.NewRGBA(image.Rect(0, 0, n, n))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cm.MapInto(img, vals, 0, 1, n)
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"slices"
+	"sort"
+	"sync"
+
+	"goki.dev/cam/hct"
+	"goki.dev/colors"
+	"goki.dev/grr"
+	"goki.dev/mat32/v2"
+)
+
+// Map2D maps two values (u, v) onto a color by interpolating between a grid
+// of colors, which can be used for encoding two variables at once, as in a
+// bivariate choropleth map. It can optionally be used in binned mode, in
+// which the values are binned into the cells of the grid instead.
+type Map2D struct {
+	// Name is the name of the color map
+	Name string
+
+	// if true, the values are binned into the cells of the grid instead of
+	// interpolated, which is typically used for bivariate choropleth maps
+	Binned bool
+
+	// the colorspace algorithm to use for blending colors
+	Blend colors.BlendTypes
+
+	// color to display for invalid numbers (e.g., NaN)
+	NoColor color.RGBA
+
+	// the grid of colors to interpolate between, as a list of rows, each of
+	// which typically has the same number of colors; the rows go from v = 0
+	// to v = 1, and the colors in each row go from u = 0 to u = 1, so rows
+	// with different numbers of colors are each spread over the full range of u
+	Colors [][]color.RGBA
+}
+
+func (m *Map2D) String() string {
+	return m.Name
+}
+
+// Map returns the color for the given normalized values in the range 0-1,
+// where u is along the rows of the grid and v is across the rows of the grid.
+// Values outside of the range are clipped, and NaN values return NoColor.
+func (m *Map2D) Map(u, v float32) color.RGBA {
+	nr := len(m.Colors)
+	if nr == 0 {
+		return color.RGBA{}
+	}
+	if mat32.IsNaN(u) || mat32.IsNaN(v) {
+		return m.NoColor
+	}
+	u, v = mat32.Clamp(u, 0, 1), mat32.Clamp(v, 0, 1)
+	// the index of the lower row or column and the proportion of the way to the next one
+	cell := func(val float32, n int) (int, float32) {
+		f := val * float32(n-1)
+		idx := min(int(f), n-2)
+		return idx, f - float32(idx)
+	}
+	// the color at u in the given row, which is indexed by its own length
+	row := func(r int) color.RGBA {
+		cs := m.Colors[r]
+		nc := len(cs)
+		switch {
+		case nc == 0:
+			return color.RGBA{}
+		case m.Binned:
+			return cs[min(int(u*float32(nc)), nc-1)]
+		case nc == 1:
+			return cs[0]
+		}
+		ci, cu := cell(u, nc)
+		return colors.Blend(m.Blend, 100*(1-cu), cs[ci], cs[ci+1])
+	}
+	if m.Binned {
+		return row(min(int(v*float32(nr)), nr-1))
+	}
+	if nr == 1 {
+		return row(0)
+	}
+	ri, rv := cell(v, nr)
+	return colors.Blend(m.Blend, 100*(1-rv), row(ri), row(ri+1))
+}
+
+// NewMap2D returns a new 2D color map with the given name that bilinearly
+// interpolates between the given four corner colors, which are at
+// (u, v) = (0, 0), (1, 0), (0, 1), and (1, 1), using the given blend type.
+func NewMap2D(name string, bt colors.BlendTypes, c00, c10, c01, c11 color.RGBA) *Map2D {
+	return &Map2D{
+		Name:    name,
+		Blend:   bt,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Colors:  [][]color.RGBA{{c00, c10}, {c01, c11}},
+	}
+}
+
+// NewHueTone returns a new 2D color map with the given name in which u is the
+// HCT hue (from 0 to 360 degrees) and v is the HCT tone (from 0 to 100),
+// with the given chroma, which is clipped to the maximum chroma available
+// for each hue and tone. The map has a grid of the given numbers of hues and
+// tones, which is interpolated in the HCT colorspace.
+func NewHueTone(name string, chroma float32, nhue, ntone int) *Map2D {
+	m := &Map2D{
+		Name:    name,
+		Blend:   colors.HCT,
+		NoColor: colors.FromRGB(200, 200, 200),
+	}
+	nhue, ntone = max(nhue, 2), max(ntone, 2)
+	for t := 0; t < ntone; t++ {
+		row := make([]color.RGBA, nhue)
+		for h := range row {
+			row[h] = hct.New(360*float32(h)/float32(nhue-1), chroma, 100*float32(t)/float32(ntone-1)).AsRGBA()
+		}
+		m.Colors = append(m.Colors, row)
+	}
+	return m
+}
+
+// bivariate returns a new binned 2D color map with the given name and
+// rows of hex colors, as used for bivariate choropleth maps.
+func bivariate(name string, rows ...[]string) *Map2D {
+	m := &Map2D{
+		Name:    name,
+		Binned:  true,
+		Blend:   colors.RGB,
+		NoColor: colors.FromRGB(200, 200, 200),
+	}
+	for _, r := range rows {
+		row := make([]color.RGBA, len(r))
+		for i, h := range r {
+			row[i] = grr.Must1(colors.FromHex(h))
+		}
+		m.Colors = append(m.Colors, row)
+	}
+	return m
+}
+
+// Clone returns a deep copy of the 2D color map.
+func (m *Map2D) Clone() *Map2D {
+	nm := *m
+	nm.Colors = make([][]color.RGBA, len(m.Colors))
+	for i, r := range m.Colors {
+		nm.Colors[i] = slices.Clone(r)
+	}
+	return &nm
+}
+
+// Register2D adds copies of the given 2D color maps to the available 2D color
+// maps based on their names, replacing any existing maps with the same names.
+// It is safe for concurrent use.
+func Register2D(ms ...*Map2D) {
+	maps2DMu.Lock()
+	defer maps2DMu.Unlock()
+	for _, m := range ms {
+		maps2D[m.Name] = m.Clone()
+	}
+}
+
+// Get2D returns a copy of the available 2D color map with the given name,
+// and whether it was found. It is safe for concurrent use.
+func Get2D(name string) (*Map2D, bool) {
+	maps2DMu.RLock()
+	defer maps2DMu.RUnlock()
+	m, ok := maps2D[name]
+	if !ok {
+		return nil, false
+	}
+	return m.Clone(), true
+}
+
+// Remove2D removes the available 2D color map with the given name,
+// if it exists. It is safe for concurrent use.
+func Remove2D(name string) {
+	maps2DMu.Lock()
+	defer maps2DMu.Unlock()
+	delete(maps2D, name)
+}
+
+// AvailMaps2DList returns a sorted list of the names of the available
+// 2D color maps, e.g., for choosers. It is safe for concurrent use.
+func AvailMaps2DList() []string {
+	maps2DMu.RLock()
+	defer maps2DMu.RUnlock()
+	sl := make([]string, 0, len(maps2D))
+	for k := range maps2D {
+		sl = append(sl, k)
+	}
+	sort.Strings(sl)
+	return sl
+}
+
+// maps2DMu is the mutex protecting maps2D
+var maps2DMu sync.RWMutex
+
+// maps2D is the map of available 2D color maps, keyed by name, which is
+// accessed through [Get2D], [Register2D], and [Remove2D]. It contains the standard 2D
+// color maps by default; the bivariate choropleth maps are by Joshua Stevens
+// (https://www.joshuastevens.net/cartography/make-a-bivariate-choropleth-map).
+// The 2D maps of Teuling et al. and Ziegler et al. are not included, as only
+// exact copies of their published reference data should be added here; they
+// can be made from that data as a [Map2D] with its grid of colors and added
+// with [Register2D].
+var maps2D = map[string]*Map2D{
+	"BivariatePinkBlue": bivariate("BivariatePinkBlue",
+		[]string{"e8e8e8", "ace4e4", "5ac8c8"},
+		[]string{"dfb0d6", "a5add3", "5698b9"},
+		[]string{"be64ac", "8c62aa", "3b4994"}),
+	"BivariateRedBlue": bivariate("BivariateRedBlue",
+		[]string{"e8e8e8", "e4acac", "c85a5a"},
+		[]string{"b0d5df", "ad9ea5", "985356"},
+		[]string{"64acbe", "627f8c", "574249"}),
+	"BivariateGreenBlue": bivariate("BivariateGreenBlue",
+		[]string{"e8e8e8", "b5c0da", "6c83b5"},
+		[]string{"b8d6be", "90b2b3", "567994"},
+		[]string{"73ae80", "5a9178", "2a5a5b"}),
+	"HueTone": NewHueTone("HueTone", 48, 13, 11),
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/grows/images"
+	"goki.dev/mat32/v2"
+)
+
+func TestMap2D(t *testing.T) {
+	m := NewMap2D("test", colors.RGB, colors.Black, colors.Red, colors.Blue, colors.White)
+	if c := m.Map(1, 0); c != colors.Red {
+		t.Errorf("expected red at (1, 0) but got %v", c)
+	}
+	if c := m.Map(0, 1); c != colors.Blue {
+		t.Errorf("expected blue at (0, 1) but got %v", c)
+	}
+	if c := m.Map(0.5, 0.5); absDiff(c.R, 128) > 1 || absDiff(c.G, 64) > 1 || absDiff(c.B, 128) > 1 {
+		t.Errorf("expected bilinear center color but got %v", c)
+	}
+	if c := m.Map(mat32.NaN(), 0); c != m.NoColor {
+		t.Errorf("expected NoColor for NaN but got %v", c)
+	}
+
+	// rows with different numbers of colors
+	r := &Map2D{Colors: [][]color.RGBA{{colors.Black}, {colors.Black, colors.Red, colors.White}}}
+	if c := r.Map(1, 1); c != colors.White {
+		t.Errorf("expected white at (1, 1) of ragged map but got %v", c)
+	}
+	if c := r.Map(1, 0); c != colors.Black {
+		t.Errorf("expected black at (1, 0) of ragged map but got %v", c)
+	}
+	r.Binned = true
+	if c := r.Map(0.5, 1); c != colors.Red {
+		t.Errorf("expected binned red at (0.5, 1) of ragged map but got %v", c)
+	}
+
+	b, ok := Get2D("BivariatePinkBlue")
+	if !ok {
+		t.Fatal("expected BivariatePinkBlue to be available")
+	}
+	if c := b.Map(0.9, 0.1); c != b.Colors[0][2] {
+		t.Errorf("expected binned color %v but got %v", b.Colors[0][2], c)
+	}
+	if c := b.Map(1, 1); c != b.Colors[2][2] {
+		t.Errorf("expected binned color %v but got %v", b.Colors[2][2], c)
+	}
+}
+
+func TestRegister2D(t *testing.T) {
+	m := NewMap2D("Custom2D", colors.RGB, colors.Black, colors.Red, colors.Blue, colors.White)
+	Register2D(m)
+	defer Remove2D("Custom2D")
+	m.Colors[0][0] = colors.White
+	g, ok := Get2D("Custom2D")
+	if !ok || !slices.Contains(AvailMaps2DList(), "Custom2D") {
+		t.Fatal("expected Custom2D to be available")
+	}
+	if g.Colors[0][0] != colors.Black {
+		t.Errorf("expected modifying a registered map to not change the available map")
+	}
+	g.Colors[1][1] = colors.Black
+	if g, _ := Get2D("Custom2D"); g.Colors[1][1] != colors.White {
+		t.Errorf("expected modifying a map from Get2D to not change the available map")
+	}
+}
+
+func TestStdMaps2D(t *testing.T) {
+	keys := AvailMaps2DList()
+	sz, gap := 96, 8
+	img := image.NewRGBA(image.Rect(0, 0, len(keys)*(sz+gap), sz))
+	for i, k := range keys {
+		m, _ := Get2D(k)
+		for y := 0; y < sz; y++ {
+			for x := 0; x < sz; x++ {
+				// v increases upward
+				img.SetRGBA(i*(sz+gap)+x, sz-1-y, m.Map(float32(x)/float32(sz-1), float32(y)/float32(sz-1)))
+			}
+		}
+	}
+	images.Assert(t, img, "colormaps2d")
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"slices"
+
+	"goki.dev/colors"
+)
+
+// paraViewXMLMap is the XML representation of a ParaView color map.
+type paraViewXMLMap struct {
+	XMLName xml.Name          `xml:"ColorMap"`
+	Name    string            `xml:"name,attr"`
+	Space   string            `xml:"space,attr"`
+	Points  []paraViewXMLRGB  `xml:"Point"`
+	NaN     *paraViewXMLColor `xml:"NaN"`
+}
+
+// paraViewXMLRGB is the XML representation of a ParaView color map point.
+type paraViewXMLRGB struct {
+	X float32 `xml:"x,attr"`
+	O float32 `xml:"o,attr"`
+	paraViewXMLColor
+}
+
+// paraViewXMLColor is the XML representation of a ParaView color.
+type paraViewXMLColor struct {
+	R float32 `xml:"r,attr"`
+	G float32 `xml:"g,attr"`
+	B float32 `xml:"b,attr"`
+}
+
+// ReadParaViewXML reads color maps from the given ParaView color map XML, which
+// has either a root ColorMaps element containing ColorMap elements or a single
+// root ColorMap element. The positions of the points are normalized to 0-1
+// and stored in [Map.Positions]. Maps with a space other than RGB or HSV use
+// the [colors.HCT] blend type, as the closest perceptual blend type.
+// The opacity of the points is ignored.
+func ReadParaViewXML(r io.Reader) ([]*Map, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var pms struct {
+		XMLName xml.Name
+		Maps    []paraViewXMLMap `xml:"ColorMap"`
+	}
+	if err := xml.Unmarshal(b, &pms); err != nil {
+		return nil, err
+	}
+	if pms.XMLName.Local == "ColorMap" {
+		var pm paraViewXMLMap
+		if err := xml.Unmarshal(b, &pm); err != nil {
+			return nil, err
+		}
+		pms.Maps = []paraViewXMLMap{pm}
+	}
+	var cms []*Map
+	for _, pm := range pms.Maps {
+		xs := make([]float32, len(pm.Points))
+		cs := make([]color.RGBA, len(pm.Points))
+		for i, p := range pm.Points {
+			xs[i] = p.X
+			cs[i] = fromFloats(p.R, p.G, p.B, 1)
+		}
+		var nan *color.RGBA
+		if pm.NaN != nil {
+			c := fromFloats(pm.NaN.R, pm.NaN.G, pm.NaN.B, 1)
+			nan = &c
+		}
+		cm, err := fromParaView(pm.Name, pm.Space, xs, cs, nan)
+		if err != nil {
+			return nil, err
+		}
+		cms = append(cms, cm)
+	}
+	return cms, nil
+}
+
+// WriteParaViewXML writes the given color maps to the given writer as
+// ParaView color map XML with a root ColorMaps element.
+func WriteParaViewXML(w io.Writer, cms ...*Map) error {
+	pms := struct {
+		XMLName xml.Name         `xml:"ColorMaps"`
+		Maps    []paraViewXMLMap `xml:"ColorMap"`
+	}{}
+	for _, cm := range cms {
+		pm := paraViewXMLMap{Name: cm.Name, Space: paraViewSpace(cm)}
+		for i, x := range cm.positions() {
+			r, g, b, _ := colors.ToFloat32(cm.Colors[i])
+			pm.Points = append(pm.Points, paraViewXMLRGB{X: x, O: 1, paraViewXMLColor: paraViewXMLColor{r, g, b}})
+		}
+		r, g, b, _ := colors.ToFloat32(cm.NoColor)
+		pm.NaN = &paraViewXMLColor{r, g, b}
+		pms.Maps = append(pms.Maps, pm)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(pms); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// paraViewJSONMap is the JSON representation of a ParaView color map.
+type paraViewJSONMap struct {
+	Name       string
+	ColorSpace string    `json:",omitempty"`
+	NanColor   []float32 `json:",omitempty"`
+	RGBPoints  []float32
+}
+
+// ReadParaViewJSON reads color maps from the given ParaView color map JSON,
+// which is an array of color map objects with RGBPoints arrays of x, r, g,
+// and b values. It handles the points like [ReadParaViewXML].
+func ReadParaViewJSON(r io.Reader) ([]*Map, error) {
+	var pms []paraViewJSONMap
+	if err := json.NewDecoder(r).Decode(&pms); err != nil {
+		return nil, err
+	}
+	var cms []*Map
+	for _, pm := range pms {
+		if len(pm.RGBPoints)%4 != 0 {
+			return nil, fmt.Errorf("colormap.ReadParaViewJSON: %q: number of RGBPoints values must be a multiple of 4", pm.Name)
+		}
+		var xs []float32
+		var cs []color.RGBA
+		for i := 0; i < len(pm.RGBPoints); i += 4 {
+			p := pm.RGBPoints[i : i+4]
+			xs = append(xs, p[0])
+			cs = append(cs, fromFloats(p[1], p[2], p[3], 1))
+		}
+		var nan *color.RGBA
+		if len(pm.NanColor) >= 3 {
+			c := fromFloats(pm.NanColor[0], pm.NanColor[1], pm.NanColor[2], 1)
+			nan = &c
+		}
+		cm, err := fromParaView(pm.Name, pm.ColorSpace, xs, cs, nan)
+		if err != nil {
+			return nil, err
+		}
+		cms = append(cms, cm)
+	}
+	return cms, nil
+}
+
+// WriteParaViewJSON writes the given color maps to the given writer as
+// ParaView color map JSON.
+func WriteParaViewJSON(w io.Writer, cms ...*Map) error {
+	pms := []paraViewJSONMap{}
+	for _, cm := range cms {
+		pm := paraViewJSONMap{Name: cm.Name, ColorSpace: paraViewSpace(cm)}
+		for i, x := range cm.positions() {
+			r, g, b, _ := colors.ToFloat32(cm.Colors[i])
+			pm.RGBPoints = append(pm.RGBPoints, x, r, g, b)
+		}
+		r, g, b, _ := colors.ToFloat32(cm.NoColor)
+		pm.NanColor = []float32{r, g, b}
+		pms = append(pms, pm)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(pms)
+}
+
+// fromParaView returns a new color map from the given ParaView color map
+// name, color space, point positions, point colors, and optional NaN color.
+func fromParaView(name, space string, xs []float32, cs []color.RGBA, nan *color.RGBA) (*Map, error) {
+	if len(xs) == 0 {
+		return nil, fmt.Errorf("colormap: ParaView color map %q has no points", name)
+	}
+	if !slices.IsSorted(xs) {
+		return nil, fmt.Errorf("colormap: points of ParaView color map %q are not sorted", name)
+	}
+	cm := &Map{
+		Name:      name,
+		NoColor:   colors.FromRGB(200, 200, 200),
+		Blend:     colors.RGB,
+		Colors:    cs,
+		Positions: xs,
+	}
+	switch space {
+	case "", "RGB", "HSV":
+	default:
+		cm.Blend = colors.HCT
+	}
+	if nan != nil {
+		cm.NoColor = *nan
+	}
+	lo, hi := xs[0], xs[len(xs)-1]
+	for i, x := range xs {
+		if hi > lo {
+			xs[i] = (x - lo) / (hi - lo)
+		} else {
+			xs[i] = 0
+		}
+	}
+	return cm, nil
+}
+
+// paraViewSpace returns the ParaView color space for the given color map.
+func paraViewSpace(cm *Map) string {
+	if cm.Blend == colors.RGB {
+		return "RGB"
+	}
+	return "Lab"
+}
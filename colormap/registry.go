@@ -0,0 +1,167 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Registry is a set of named color maps that is safe for concurrent use.
+// It stores copies of the maps that are registered in it, and it returns
+// copies of them, so the maps can be freely modified by the code that
+// registers or gets them without affecting other users of the registry.
+// Unlike [Map.Clone], the copies keep the lookup tables of the maps (see
+// [Map.UseLUT]), so a map can be registered with a lookup table for all of
+// the users of the registry. It must be made with [NewRegistry].
+type Registry struct {
+	// the maps in the registry, keyed by name
+	maps map[string]*Map
+
+	// mutex protecting maps
+	mu sync.RWMutex
+}
+
+// DefaultRegistry is the default registry of available color maps,
+// which contains all of the standard color maps by default. It is used
+// by the package-level functions such as [Get], [Register], and [AvailMapsList].
+var DefaultRegistry = NewRegistry()
+
+// AvailMaps is a copy of the color maps that are in the [DefaultRegistry]
+// by default, keyed by name. It is not kept in sync with the registry:
+// maps that are registered or removed later are not reflected in it, and
+// modifying it does not affect the registry.
+//
+// Deprecated: use [Get], [Register], [Remove], and [AvailMapsList] instead.
+var AvailMaps = cloneMaps(stdMaps)
+
+// NewRegistry returns a new [Registry] containing the given color maps.
+func NewRegistry(cms ...*Map) *Registry {
+	r := &Registry{maps: map[string]*Map{}}
+	r.Register(cms...)
+	return r
+}
+
+// Register adds copies of the given color maps to the registry based on
+// their names, replacing any existing maps with the same names.
+func (r *Registry) Register(cms ...*Map) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cm := range cms {
+		r.maps[cm.Name] = cm.cloneLUT()
+	}
+}
+
+// Get returns a copy of the color map with the given name in the
+// registry, and whether it was found.
+func (r *Registry) Get(name string) (*Map, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	cm, ok := r.maps[name]
+	if !ok {
+		return nil, false
+	}
+	return cm.cloneLUT(), true
+}
+
+// Has returns whether the registry has a color map with the given name.
+func (r *Registry) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.maps[name]
+	return ok
+}
+
+// Remove removes the color map with the given name from the registry,
+// if it exists.
+func (r *Registry) Remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.maps, name)
+}
+
+// Names returns a sorted list of the names of the color maps in the registry.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sl := make([]string, 0, len(r.maps))
+	for k := range r.maps {
+		sl = append(sl, k)
+	}
+	sort.Strings(sl)
+	return sl
+}
+
+// NamesOfKind returns a sorted list of the names of the color maps
+// of the given kind in the registry.
+func (r *Registry) NamesOfKind(kind Kinds) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sl := []string{}
+	for k, cm := range r.maps {
+		if cm.Kind == kind {
+			sl = append(sl, k)
+		}
+	}
+	sort.Strings(sl)
+	return sl
+}
+
+// NamesByKind returns the sorted names of all of the color maps
+// in the registry grouped by their kind.
+func (r *Registry) NamesByKind() map[Kinds][]string {
+	res := map[Kinds][]string{}
+	for _, kind := range KindsValues() {
+		res[kind] = r.NamesOfKind(kind)
+	}
+	return res
+}
+
+// Register adds copies of the given color maps to the [DefaultRegistry]
+// (see [Registry.Register]).
+func Register(cms ...*Map) {
+	DefaultRegistry.Register(cms...)
+}
+
+// Get returns a copy of the color map with the given name in the
+// [DefaultRegistry], and whether it was found (see [Registry.Get]).
+func Get(name string) (*Map, bool) {
+	return DefaultRegistry.Get(name)
+}
+
+// Remove removes the color map with the given name from the
+// [DefaultRegistry], if it exists (see [Registry.Remove]).
+func Remove(name string) {
+	DefaultRegistry.Remove(name)
+}
+
+// Clone returns a deep copy of the color map. The copy does not have the
+// lookup table of the color map (see [Map.UseLUT]), as it would be out of
+// date if the colors of the copy are modified.
+func (cm *Map) Clone() *Map {
+	nm := *cm
+	nm.Colors = slices.Clone(cm.Colors)
+	nm.Positions = slices.Clone(cm.Positions)
+	nm.lut = nil
+	return &nm
+}
+
+// cloneMaps returns a deep copy of the given map of color maps.
+func cloneMaps(cms map[string]*Map) map[string]*Map {
+	res := make(map[string]*Map, len(cms))
+	for k, cm := range cms {
+		res[k] = cm.cloneLUT()
+	}
+	return res
+}
+
+// cloneLUT returns a deep copy of the color map that shares its lookup table,
+// which is safe as lookup tables are replaced instead of modified by [Map.UseLUT].
+func (cm *Map) cloneLUT() *Map {
+	nm := cm.Clone()
+	nm.lut = cm.lut
+	return nm
+}
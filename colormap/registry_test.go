@@ -0,0 +1,80 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"goki.dev/colors"
+)
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(stdMaps["Viridis"])
+	cm, ok := r.Get("Viridis")
+	if !ok {
+		t.Fatal("expected Viridis to be registered")
+	}
+	cm.Blend = colors.CAM16
+	cm.Colors[0] = colors.White
+	if cm, _ := r.Get("Viridis"); cm.Blend != colors.RGB || cm.Colors[0] == colors.White {
+		t.Errorf("expected modifying a map from Get to not change the registry")
+	}
+	if stdMaps["Viridis"].Colors[0] == colors.White {
+		t.Errorf("expected modifying a map from Get to not change stdMaps")
+	}
+
+	cm.Name = "Custom"
+	r.Register(cm)
+	if !slices.Equal(r.Names(), []string{"Custom", "Viridis"}) {
+		t.Errorf("expected Custom and Viridis but got %v", r.Names())
+	}
+	r.Remove("Viridis")
+	if r.Has("Viridis") || !r.Has("Custom") {
+		t.Errorf("expected only Custom after removing Viridis but got %v", r.Names())
+	}
+
+	if !DefaultRegistry.Has("Viridis") || len(AvailMapsList()) != len(stdMaps) {
+		t.Errorf("expected default registry to contain the standard maps")
+	}
+	if len(AvailMaps) != len(stdMaps) || AvailMaps["Viridis"] == nil || len(StdMaps) != len(stdMaps) {
+		t.Errorf("expected AvailMaps and StdMaps to contain the standard maps")
+	}
+	Register(&Map{Name: "Custom"})
+	defer Remove("Custom")
+	if AvailMaps["Custom"] != nil {
+		t.Errorf("expected registering a map to not change AvailMaps")
+	}
+	AvailMaps["Viridis"].Colors[0] = colors.White
+	defer func() { AvailMaps["Viridis"].Colors[0] = stdMaps["Viridis"].Colors[0] }()
+	if cm, _ := Get("Viridis"); cm.Colors[0] == colors.White {
+		t.Errorf("expected modifying AvailMaps to not change the default registry")
+	}
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	r := NewRegistry()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("Map%d", i)
+			for j := 0; j < 100; j++ {
+				r.Register(&Map{Name: name, Colors: stdMaps["Jet"].Colors})
+				r.Get(name)
+				r.Names()
+				r.Remove(name)
+			}
+			r.Register(&Map{Name: name})
+		}(i)
+	}
+	wg.Wait()
+	if n := len(r.Names()); n != 8 {
+		t.Errorf("expected 8 maps but got %d", n)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"slices"
+	"sort"
+
+	"goki.dev/mat32/v2"
+)
+
+// Normalizer converts raw data values into normalized values,
+// where values in the range of the data map onto the range 0-1.
+// Values outside of the range of the data may map onto values
+// outside of 0-1, which [Scale] handles by clipping them or using
+// its under and over colors. Invalid values should map onto NaN.
+type Normalizer interface {
+	// Normalize returns the normalized value for the given raw value.
+	Normalize(val float32) float32
+}
+
+// LinearNorm is a [Normalizer] that linearly maps the range from Min to Max onto 0-1.
+type LinearNorm struct {
+	// the value that maps onto 0
+	Min float32
+
+	// the value that maps onto 1
+	Max float32
+}
+
+func (n *LinearNorm) Normalize(val float32) float32 {
+	if n.Max == n.Min {
+		return 0
+	}
+	return (val - n.Min) / (n.Max - n.Min)
+}
+
+// LogNorm is a [Normalizer] that maps the range from Min to Max onto 0-1 on a
+// logarithmic scale. Min and Max must be positive, and values that are not
+// positive map onto NaN.
+type LogNorm struct {
+	// the value that maps onto 0
+	Min float32
+
+	// the value that maps onto 1
+	Max float32
+}
+
+func (n *LogNorm) Normalize(val float32) float32 {
+	if val <= 0 {
+		return mat32.NaN()
+	}
+	lmin, lmax := mat32.Log10(n.Min), mat32.Log10(n.Max)
+	if lmax == lmin {
+		return 0
+	}
+	return (mat32.Log10(val) - lmin) / (lmax - lmin)
+}
+
+// SymLogNorm is a [Normalizer] that maps the range from Min to Max onto 0-1 on a
+// symmetrical logarithmic scale, which is logarithmic in both the positive and
+// negative directions from zero, and linear within LinThresh of zero. Unlike
+// [LogNorm], it supports data that includes zero and negative values.
+type SymLogNorm struct {
+	// the value that maps onto 0
+	Min float32
+
+	// the value that maps onto 1
+	Max float32
+
+	// the range (-LinThresh, LinThresh) within which the scale is linear;
+	// it must be positive
+	LinThresh float32
+}
+
+func (n *SymLogNorm) Normalize(val float32) float32 {
+	tmin, tmax := n.transform(n.Min), n.transform(n.Max)
+	if tmax == tmin {
+		return 0
+	}
+	return (n.transform(val) - tmin) / (tmax - tmin)
+}
+
+// transform applies the symmetrical logarithmic transform to the given value.
+func (n *SymLogNorm) transform(val float32) float32 {
+	return mat32.Copysign(mat32.Log10(1+mat32.Abs(val)/n.LinThresh), val)
+}
+
+// PowerNorm is a [Normalizer] that linearly maps the range from Min to Max onto
+// 0-1 and then raises the result to the power of Gamma. A Gamma of less than 1
+// emphasizes differences between small values, and a Gamma of greater than 1
+// emphasizes differences between large values.
+type PowerNorm struct {
+	// the value that maps onto 0
+	Min float32
+
+	// the value that maps onto 1
+	Max float32
+
+	// the exponent to raise normalized values to
+	Gamma float32
+}
+
+func (n *PowerNorm) Normalize(val float32) float32 {
+	ln := LinearNorm{Min: n.Min, Max: n.Max}
+	v := ln.Normalize(val)
+	if v < 0 || v > 1 {
+		return v
+	}
+	return mat32.Pow(v, n.Gamma)
+}
+
+// DivergingNorm is a [Normalizer] for use with [Diverging] maps that maps the
+// range from Min to Center onto 0-0.5 and the range from Center to Max onto
+// 0.5-1, such that Center always maps onto the neutral center of the map even
+// if the data is not symmetrical around it.
+type DivergingNorm struct {
+	// the value that maps onto 0
+	Min float32
+
+	// the value that maps onto 0.5, which is typically 0
+	Center float32
+
+	// the value that maps onto 1
+	Max float32
+}
+
+func (n *DivergingNorm) Normalize(val float32) float32 {
+	if val < n.Center {
+		if n.Center == n.Min {
+			return 0
+		}
+		return 0.5 * (val - n.Min) / (n.Center - n.Min)
+	}
+	if n.Max == n.Center {
+		return 1
+	}
+	return 0.5 + 0.5*(val-n.Center)/(n.Max-n.Center)
+}
+
+// QuantileNorm is a [Normalizer] that maps values onto their quantile
+// (fractional rank) in a set of data, which results in each color of the map
+// being used for an equal amount of the data. It must be made with [NewQuantileNorm].
+// Values between data values are linearly interpolated, and values outside of the
+// range of the data are clipped to 0-1.
+type QuantileNorm struct {
+	// the sorted data values
+	sorted []float32
+}
+
+// NewQuantileNorm returns a new [QuantileNorm] for the given data, ignoring NaN values.
+// It does not modify the given data.
+func NewQuantileNorm(data []float32) *QuantileNorm {
+	n := &QuantileNorm{}
+	for _, v := range data {
+		if !mat32.IsNaN(v) {
+			n.sorted = append(n.sorted, v)
+		}
+	}
+	slices.Sort(n.sorted)
+	return n
+}
+
+func (n *QuantileNorm) Normalize(val float32) float32 {
+	ns := len(n.sorted)
+	if ns == 0 || mat32.IsNaN(val) {
+		return mat32.NaN()
+	}
+	if ns == 1 || val <= n.sorted[0] {
+		return 0
+	}
+	if val >= n.sorted[ns-1] {
+		return 1
+	}
+	// first value greater than val
+	uidx := sort.Search(ns, func(i int) bool {
+		return n.sorted[i] > val
+	})
+	lidx := uidx - 1
+	lv, uv := n.sorted[lidx], n.sorted[uidx]
+	return (float32(lidx) + (val-lv)/(uv-lv)) / float32(ns-1)
+}
+
+// Scale maps raw data values onto colors using a [Normalizer] and a [Map].
+// It is typically made with [NewScale] or [NewLinearScale].
+type Scale struct {
+	// the color map to use for normalized values
+	Colormap *Map
+
+	// the normalizer to use for converting raw values into normalized values
+	Norm Normalizer
+
+	// whether to clip normalized values outside of 0-1 to the colors at the
+	// ends of the map; if false, the Under and Over colors are used instead,
+	// which is useful for distinguishing out-of-range values
+	Clip bool
+
+	// the color to use for values that normalize below 0 if Clip is false
+	Under color.RGBA
+
+	// the color to use for values that normalize above 1 if Clip is false
+	Over color.RGBA
+}
+
+// NewScale returns a new [Scale] for the given color map and normalizer,
+// with clipping turned on.
+func NewScale(cm *Map, norm Normalizer) *Scale {
+	return &Scale{Colormap: cm, Norm: norm, Clip: true}
+}
+
+// NewLinearScale returns a new [Scale] for the given color map that
+// linearly maps values from the given min to max (see [LinearNorm]).
+func NewLinearScale(cm *Map, min, max float32) *Scale {
+	return NewScale(cm, &LinearNorm{Min: min, Max: max})
+}
+
+// Map returns the color for the given raw value. NaN values and values
+// that normalize onto NaN return the NoColor of the color map.
+func (s *Scale) Map(val float32) color.RGBA {
+	if mat32.IsNaN(val) {
+		return s.Colormap.NoColor
+	}
+	nv := s.Norm.Normalize(val)
+	if !s.Clip {
+		if nv < 0 {
+			return s.Under
+		} else if nv > 1 {
+			return s.Over
+		}
+	}
+	return s.Colormap.Map(nv)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestNormalizers(t *testing.T) {
+	tests := []struct {
+		name string
+		norm Normalizer
+		val  float32
+		want float32
+	}{
+		{"linear", &LinearNorm{Min: 10, Max: 20}, 15, 0.5},
+		{"linear-under", &LinearNorm{Min: 10, Max: 20}, 5, -0.5},
+		{"log", &LogNorm{Min: 1, Max: 1000}, 10, 1.0 / 3},
+		{"log-invalid", &LogNorm{Min: 1, Max: 1000}, -1, mat32.NaN()},
+		{"symlog-zero", &SymLogNorm{Min: -100, Max: 100, LinThresh: 1}, 0, 0.5},
+		{"symlog-neg", &SymLogNorm{Min: -99, Max: 99, LinThresh: 1}, -9, 0.25},
+		{"power", &PowerNorm{Min: 0, Max: 10, Gamma: 2}, 5, 0.25},
+		{"diverging-low", &DivergingNorm{Min: -10, Center: 0, Max: 100}, -5, 0.25},
+		{"diverging-high", &DivergingNorm{Min: -10, Center: 0, Max: 100}, 50, 0.75},
+		{"quantile", NewQuantileNorm([]float32{1, 2, 100, 1000, mat32.NaN()}), 100, 2.0 / 3},
+		{"quantile-between", NewQuantileNorm([]float32{1, 2, 100, 1000}), 1.5, 1.0 / 6},
+	}
+	for _, test := range tests {
+		have := test.norm.Normalize(test.val)
+		if mat32.IsNaN(test.want) {
+			if !mat32.IsNaN(have) {
+				t.Errorf("%s: expected NaN but got %g", test.name, have)
+			}
+			continue
+		}
+		if mat32.Abs(have-test.want) > 1e-5 {
+			t.Errorf("%s: expected %g but got %g", test.name, test.want, have)
+		}
+	}
+}
+
+func TestScale(t *testing.T) {
+	cm := &Map{
+		Blend:   colors.RGB,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Colors:  []color.RGBA{colors.Black, colors.White},
+	}
+	s := NewLinearScale(cm, 0, 10)
+	if c := s.Map(-5); c != colors.Black {
+		t.Errorf("expected clipped under value to be black but got %v", c)
+	}
+	if c := s.Map(mat32.NaN()); c != cm.NoColor {
+		t.Errorf("expected NaN to be NoColor but got %v", c)
+	}
+	s.Clip = false
+	s.Under, s.Over = colors.Blue, colors.Red
+	if c := s.Map(-5); c != colors.Blue {
+		t.Errorf("expected under value to be blue but got %v", c)
+	}
+	if c := s.Map(15); c != colors.Red {
+		t.Errorf("expected over value to be red but got %v", c)
+	}
+	if c := s.Map(10); c != colors.White {
+		t.Errorf("expected max value to be white but got %v", c)
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"goki.dev/colors"
+)
+
+// Segment is one anchor point of a color channel in matplotlib segmented
+// data (see [FromSegmented]), which corresponds to an (x, y0, y1) tuple.
+type Segment struct {
+	// X is the position of the anchor point in the range 0-1
+	X float32
+
+	// Below is the value of the channel (0-1) just below X
+	Below float32
+
+	// Above is the value of the channel (0-1) just above X
+	Above float32
+}
+
+// FromSegmented returns a new color map with the given name based on the given
+// matplotlib segmented data (the segmentdata of a LinearSegmentedColormap) for
+// the red, green, and blue channels. The anchor points of each channel must
+// start at 0, end at 1, and be sorted by position. The resulting map has a
+// color at each anchor point of any channel, using [Map.Positions], and it
+// has two colors at anchor points where the value below and above differ
+// in any channel, which results in a sharp transition.
+func FromSegmented(name string, red, green, blue []Segment) (*Map, error) {
+	chans := [3][]Segment{red, green, blue}
+	var xs []float32
+	for ci, ch := range chans {
+		n := len(ch)
+		if n < 2 || ch[0].X != 0 || ch[n-1].X != 1 {
+			return nil, fmt.Errorf("colormap.FromSegmented: channel %d must have at least two anchor points starting at 0 and ending at 1", ci)
+		}
+		for i, s := range ch {
+			if i > 0 && s.X < ch[i-1].X {
+				return nil, fmt.Errorf("colormap.FromSegmented: anchor points of channel %d are not sorted (%g after %g)", ci, s.X, ch[i-1].X)
+			}
+			xs = append(xs, s.X)
+		}
+	}
+	slices.Sort(xs)
+	xs = slices.Compact(xs)
+
+	cm := &Map{
+		Name:    name,
+		NoColor: colors.FromRGB(200, 200, 200),
+		Blend:   colors.RGB,
+	}
+	add := func(x float32, c [3]float32) {
+		cm.Colors = append(cm.Colors, fromFloats(c[0], c[1], c[2], 1))
+		cm.Positions = append(cm.Positions, x)
+	}
+	for _, x := range xs {
+		var below, above [3]float32
+		for ci, ch := range chans {
+			below[ci], above[ci] = segmentValues(ch, x)
+		}
+		switch {
+		case x == 0:
+			add(x, above)
+		case x == 1:
+			add(x, below)
+		default:
+			if below != above {
+				add(x, below)
+			}
+			add(x, above)
+		}
+	}
+	return cm, nil
+}
+
+// segmentValues returns the values of the given channel anchor points
+// just below and just above the given position.
+func segmentValues(ch []Segment, x float32) (below, above float32) {
+	// first anchor point at or after x
+	idx, _ := slices.BinarySearchFunc(ch, x, func(s Segment, x float32) int {
+		switch {
+		case s.X < x:
+			return -1
+		case s.X > x:
+			return 1
+		}
+		return 0
+	})
+	if ch[idx].X == x {
+		below = ch[idx].Below
+		// the last anchor point at x determines the value above it
+		for idx+1 < len(ch) && ch[idx+1].X == x {
+			idx++
+		}
+		return below, ch[idx].Above
+	}
+	// x is between two anchor points, so we linearly interpolate
+	// from the value above the previous one to the value below the next one
+	lo, hi := ch[idx-1], ch[idx]
+	v := lo.Above + (hi.Below-lo.Above)*(x-lo.X)/(hi.X-lo.X)
+	return v, v
+}
+
+// segmentedNumber matches a number in Python segmented data.
+var segmentedNumber = regexp.MustCompile(`[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?`)
+
+// ParseSegmented returns a new color map with the given name based on the given
+// Python source code of matplotlib segmented data, which is a dictionary with
+// 'red', 'green', and 'blue' keys that each have a list or tuple of (x, y0, y1)
+// tuples as their value, as in the source code of matplotlib. It converts the
+// data using [FromSegmented]. Any other keys, such as 'alpha', are ignored.
+func ParseSegmented(name, src string) (*Map, error) {
+	var chans [3][]Segment
+	for ci, key := range []string{"red", "green", "blue"} {
+		re := regexp.MustCompile(`['"]` + key + `['"]\s*:`)
+		loc := re.FindStringIndex(src)
+		if loc == nil {
+			return nil, fmt.Errorf("colormap.ParseSegmented: missing %q key", key)
+		}
+		val := src[loc[1]:]
+		start := strings.IndexAny(val, "[(")
+		if start < 0 {
+			return nil, fmt.Errorf("colormap.ParseSegmented: missing list for %q key", key)
+		}
+		// find the matching closing bracket
+		depth, end := 0, -1
+		for i := start; i < len(val) && end < 0; i++ {
+			switch val[i] {
+			case '[', '(':
+				depth++
+			case ']', ')':
+				depth--
+				if depth == 0 {
+					end = i
+				}
+			}
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("colormap.ParseSegmented: unterminated list for %q key", key)
+		}
+		nums := segmentedNumber.FindAllString(val[start:end], -1)
+		if len(nums)%3 != 0 {
+			return nil, fmt.Errorf("colormap.ParseSegmented: number of values for %q key must be a multiple of 3", key)
+		}
+		for i := 0; i < len(nums); i += 3 {
+			var vs [3]float32
+			for j := range vs {
+				v, err := strconv.ParseFloat(nums[i+j], 32)
+				if err != nil {
+					return nil, fmt.Errorf("colormap.ParseSegmented: %w", err)
+				}
+				vs[j] = float32(v)
+			}
+			chans[ci] = append(chans[ci], Segment{X: vs[0], Below: vs[1], Above: vs[2]})
+		}
+	}
+	return FromSegmented(name, chans[0], chans[1], chans[2])
+}
+
+// WriteSegmented writes the given color map to the given writer as the Python
+// source code of matplotlib segmented data, which can be read with [ParseSegmented]
+// and used to make a LinearSegmentedColormap in matplotlib. Two colors at the
+// same position (see [Map.Positions]) result in a sharp transition.
+func WriteSegmented(w io.Writer, cm *Map) error {
+	bw := bufio.NewWriter(w)
+	ps := cm.positions()
+	nc := len(cm.Colors)
+	for ci, key := range []string{"red", "green", "blue"} {
+		channel := func(c color.RGBA) float32 {
+			r, g, b, _ := colors.ToFloat32(c)
+			return [3]float32{r, g, b}[ci]
+		}
+		if ci == 0 {
+			bw.WriteString("{")
+		} else {
+			bw.WriteString(",\n ")
+		}
+		fmt.Fprintf(bw, "'%s': [", key)
+		for i := 0; i < nc; i++ {
+			if i > 0 {
+				bw.WriteString(", ")
+			}
+			x := ps[i]
+			below, above := channel(cm.Colors[i]), channel(cm.Colors[i])
+			if i+1 < nc && ps[i+1] == x {
+				i++
+				above = channel(cm.Colors[i])
+			}
+			fmt.Fprintf(bw, "(%g, %g, %g)", x, below, above)
+		}
+		bw.WriteString("]")
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
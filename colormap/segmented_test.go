@@ -0,0 +1,70 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/colors"
+)
+
+func TestPositions(t *testing.T) {
+	cm := &Map{
+		Blend:     colors.RGB,
+		Colors:    []color.RGBA{colors.Black, colors.White, colors.Black, colors.White},
+		Positions: []float32{0, 0.2, 0.2, 1},
+	}
+	tests := []struct {
+		val  float32
+		want color.RGBA
+	}{
+		{-1, colors.Black},
+		{0.1, color.RGBA{128, 128, 128, 255}},
+		{0.2, colors.Black},
+		{0.6, color.RGBA{128, 128, 128, 255}},
+		{1, colors.White},
+		{2, colors.White},
+	}
+	for _, test := range tests {
+		have := cm.Map(test.val)
+		if absDiff(have.R, test.want.R) > 1 || have.A != test.want.A {
+			t.Errorf("%g: expected %v but got %v", test.val, test.want, have)
+		}
+	}
+}
+
+func TestFromSegmented(t *testing.T) {
+	red := []Segment{{0, 0, 0}, {0.5, 0, 1}, {1, 1, 1}}
+	green := []Segment{{0, 0, 0}, {1, 1, 1}}
+	blue := []Segment{{0, 0, 0}, {1, 0, 0}}
+	cm, err := FromSegmented("test", red, green, blue)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cm.Colors) != 4 || len(cm.Positions) != 4 {
+		t.Fatalf("expected 4 colors with positions but got %v and %v", cm.Colors, cm.Positions)
+	}
+	tests := []struct {
+		val  float32
+		want color.RGBA
+	}{
+		{0.25, color.RGBA{0, 64, 0, 255}},
+		{0.49, color.RGBA{0, 125, 0, 255}},
+		{0.51, color.RGBA{255, 130, 0, 255}},
+		{1, color.RGBA{255, 255, 0, 255}},
+	}
+	for _, test := range tests {
+		have := cm.Map(test.val)
+		if absDiff(have.R, test.want.R) > 1 || absDiff(have.G, test.want.G) > 1 || have.B != test.want.B {
+			t.Errorf("%g: expected %v but got %v", test.val, test.want, have)
+		}
+	}
+
+	_, err = FromSegmented("bad", []Segment{{0.1, 0, 0}, {1, 1, 1}}, green, blue)
+	if err == nil {
+		t.Errorf("expected error for segments not starting at 0")
+	}
+}
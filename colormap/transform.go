@@ -0,0 +1,141 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"slices"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+// Reverse returns a new color map that is the reverse of this map, such that
+// the color at value v in the new map is the color at value 1-v in this map.
+// The name of the new map is the name of this map with an _r suffix, as in
+// matplotlib (for example, Jet_r).
+func (cm *Map) Reverse() *Map {
+	nm := cm.Clone()
+	nm.Name = cm.Name + "_r"
+	slices.Reverse(nm.Colors)
+	if len(nm.Positions) > 0 {
+		slices.Reverse(nm.Positions)
+		for i, p := range nm.Positions {
+			nm.Positions[i] = 1 - p
+		}
+	}
+	return nm
+}
+
+// Truncate returns a new color map that is the part of this map between the
+// given low and high values (in the range 0-1), such that the color at value v
+// in the new map is the color at value lo + v*(hi-lo) in this map. The new map
+// uses [Map.Positions] to keep the colors of this map at the same relative
+// positions. If lo is greater than hi, the new map is reversed, and if they
+// are equal, it only has the color at lo. Truncated [Cyclic] maps become
+// [Sequential] maps.
+func (cm *Map) Truncate(lo, hi float32) *Map {
+	lo, hi = mat32.Clamp(lo, 0, 1), mat32.Clamp(hi, 0, 1)
+	if lo > hi {
+		nm := cm.Truncate(hi, lo).Reverse()
+		nm.Name = cm.Name
+		return nm
+	}
+	nm := cm.Clone()
+	if lo == hi {
+		c := cm.Map(lo)
+		nm.Colors = []color.RGBA{c, c}
+		nm.Positions = nil
+		if nm.Kind == Cyclic {
+			nm.Kind = Sequential
+		}
+		return nm
+	}
+	nm.Colors = []color.RGBA{cm.Map(lo)}
+	nm.Positions = []float32{0}
+	for i, p := range cm.positions() {
+		if p > lo && p < hi {
+			nm.Colors = append(nm.Colors, cm.Colors[i])
+			nm.Positions = append(nm.Positions, (p-lo)/(hi-lo))
+		}
+	}
+	nm.Colors = append(nm.Colors, cm.Map(hi))
+	nm.Positions = append(nm.Positions, 1)
+	if nm.Kind == Cyclic {
+		nm.Kind = Sequential
+	}
+	return nm
+}
+
+// Concat returns a new color map that joins the two given color maps at the
+// given split value (in the range 0-1), such that the first map is scaled
+// into the range 0-split and the second map is scaled into the range split-1.
+// For example, joining a reversed blue map and a red map at 0.5 results in
+// a diverging map. The new map uses [Map.Positions], and it has the name,
+// blend type, and NoColor of the first map, with the name of the second map
+// appended to the name. If both maps are [Sequential], the new map is
+// [Diverging]; otherwise, it has the kind of the first map.
+func Concat(a, b *Map, split float32) *Map {
+	nm := a.Clone()
+	nm.Name = a.Name + b.Name
+	nm.CVDSafe = a.CVDSafe && b.CVDSafe
+	nm.Source, nm.License = "", ""
+	if a.Kind == Sequential && b.Kind == Sequential {
+		nm.Kind = Diverging
+	}
+	split = mat32.Clamp(split, 0, 1)
+	nm.Positions = nil
+	for _, p := range a.positions() {
+		nm.Positions = append(nm.Positions, p*split)
+	}
+	nm.Colors = append(nm.Colors, b.Colors...)
+	for _, p := range b.positions() {
+		nm.Positions = append(nm.Positions, split+p*(1-split))
+	}
+	return nm
+}
+
+// Shift returns a new color map that is this map shifted by the given amount
+// (in the range 0-1) with wrapping around, such that the color at value v in
+// the new map is the color at value v+amount (modulo 1) in this map. It is
+// intended for [Cyclic] maps, for which it changes the value at which the
+// cycle starts. The new map uses [Map.Positions].
+func (cm *Map) Shift(amount float32) *Map {
+	nm := cm.Clone()
+	s := amount - mat32.Floor(amount)
+	wrap := cm.Map(s)
+	nm.Colors = []color.RGBA{wrap}
+	nm.Positions = []float32{0}
+	ps := cm.positions()
+	for i, p := range ps {
+		if p > s {
+			nm.Colors = append(nm.Colors, cm.Colors[i])
+			nm.Positions = append(nm.Positions, p-s)
+		}
+	}
+	for i, p := range ps {
+		if p < s {
+			nm.Colors = append(nm.Colors, cm.Colors[i])
+			nm.Positions = append(nm.Positions, p-s+1)
+		}
+	}
+	nm.Colors = append(nm.Colors, wrap)
+	nm.Positions = append(nm.Positions, 1)
+	return nm
+}
+
+// Resample returns a new color map with the given number of evenly spaced
+// colors, which are sampled from this map by blending its colors with the
+// given blend type, or taken in order for a [Map.Indexed] map (see [Map.Sample]). The new map uses the given blend type.
+// This is useful for converting a map into a different blend space, as the
+// colors of the new map are close enough together that the blend type used
+// to blend them has little effect.
+func (cm *Map) Resample(n int, bt colors.BlendTypes) *Map {
+	nm := cm.Clone()
+	nm.Blend = bt
+	nm.Colors = nm.Sample(n)
+	nm.Positions = nil
+	return nm
+}
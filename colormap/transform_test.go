@@ -0,0 +1,87 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colormap
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/colors"
+)
+
+// expectMapAt checks that the given maps have the same colors at the
+// given pairs of values in the first map and the second map.
+func expectMapAt(t *testing.T, want, have *Map, vals ...[2]float32) {
+	t.Helper()
+	for _, v := range vals {
+		w, h := want.Map(v[0]), have.Map(v[1])
+		if absDiff(w.R, h.R) > 1 || absDiff(w.G, h.G) > 1 || absDiff(w.B, h.B) > 1 {
+			t.Errorf("expected %v at %g but got %v at %g", w, v[0], h, v[1])
+		}
+	}
+}
+
+func TestReverseTruncate(t *testing.T) {
+	jet := stdMaps["Jet"]
+	r := jet.Reverse()
+	if r.Name != "Jet_r" {
+		t.Errorf("expected name Jet_r but got %q", r.Name)
+	}
+	expectMapAt(t, jet, r, [2]float32{0, 1}, [2]float32{0.3, 0.7}, [2]float32{1, 0})
+
+	tr := stdMaps["Viridis"].Truncate(0.25, 0.75)
+	expectMapAt(t, stdMaps["Viridis"], tr, [2]float32{0.25, 0}, [2]float32{0.5, 0.5}, [2]float32{0.6, 0.7}, [2]float32{0.75, 1})
+	rt := stdMaps["Viridis"].Truncate(0.75, 0.25)
+	if rt.Name != "Viridis" {
+		t.Errorf("expected name Viridis but got %q", rt.Name)
+	}
+	expectMapAt(t, stdMaps["Viridis"], rt, [2]float32{0.75, 0}, [2]float32{0.5, 0.5}, [2]float32{0.6, 0.3}, [2]float32{0.25, 1})
+	et := stdMaps["Viridis"].Truncate(0.4, 0.4)
+	expectMapAt(t, stdMaps["Viridis"], et, [2]float32{0.4, 0}, [2]float32{0.4, 0.5}, [2]float32{0.4, 1})
+
+	sharp := &Map{
+		Blend:     colors.RGB,
+		Colors:    []color.RGBA{colors.Black, colors.White, colors.Black, colors.White},
+		Positions: []float32{0, 0.5, 0.5, 1},
+	}
+	sr := sharp.Reverse()
+	expectMapAt(t, sharp, sr, [2]float32{0.25, 0.75}, [2]float32{0.75, 0.25})
+}
+
+func TestConcatShift(t *testing.T) {
+	blues := stdMaps["Blues"].Reverse()
+	reds := stdMaps["Reds"]
+	c := Concat(blues, reds, 0.4)
+	if c.Kind != Diverging || !c.CVDSafe {
+		t.Errorf("expected colorblind safe diverging map but got %v %v", c.Kind, c.CVDSafe)
+	}
+	expectMapAt(t, blues, c, [2]float32{0, 0}, [2]float32{0.5, 0.2})
+	expectMapAt(t, reds, c, [2]float32{0.5, 0.7}, [2]float32{1, 1})
+
+	cyc := stdMaps["DarkLightDark"]
+	s := cyc.Shift(1.25)
+	expectMapAt(t, cyc, s, [2]float32{0.25, 0}, [2]float32{0.5, 0.25}, [2]float32{0.1, 0.85}, [2]float32{0.25, 1})
+}
+
+func TestResample(t *testing.T) {
+	cm := stdMaps["BlueRed"]
+	r := cm.Resample(64, colors.HCT)
+	if r.Blend != colors.HCT || len(r.Colors) != 64 {
+		t.Fatalf("expected 64 HCT colors but got %d %v", len(r.Colors), r.Blend)
+	}
+	hct := cm.Clone()
+	hct.Blend = colors.HCT
+	expectMapAt(t, hct, r, [2]float32{0, 0}, [2]float32{1, 1})
+	// the resampled colors are the same as the colors of the map at their
+	// values, and the colors between them are close to those of the map
+	for _, i := range []int{1, 21, 32, 50, 62} {
+		v := float32(i) / 63
+		expectMapAt(t, hct, r, [2]float32{v, v})
+	}
+	expectMapAt(t, hct, r, [2]float32{0.3, 0.3}, [2]float32{0.55, 0.55}, [2]float32{0.8, 0.8})
+	if len(cm.Colors) != 2 {
+		t.Errorf("expected original map to be unchanged")
+	}
+}
@@ -213,3 +213,8 @@ func ExampleSub() {
 	fmt.Println(Sub(Purple, Blue))
 	// Output: {128 0 0 0}
 }
+
+func ExampleDeltaE() {
+	fmt.Printf("%.2f %.2f\n", DeltaE(Red, Red), DeltaE(Black, White))
+	// Output: 0.00 25.66
+}
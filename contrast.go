@@ -0,0 +1,199 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colors
+
+import (
+	"image/color"
+
+	"goki.dev/cam/cie"
+	"goki.dev/cam/hct"
+	"goki.dev/mat32/v2"
+)
+
+const (
+	// ContrastAA is the minimum WCAG 2 contrast ratio (see [ContrastRatio])
+	// of normal text with its background for conformance level AA.
+	ContrastAA float32 = 4.5
+
+	// ContrastAALarge is the minimum WCAG 2 contrast ratio of large text
+	// (at least 18pt, or 14pt bold) and user interface components with
+	// their background for conformance level AA.
+	ContrastAALarge float32 = 3
+
+	// ContrastAAA is the minimum WCAG 2 contrast ratio of normal text
+	// with its background for conformance level AAA.
+	ContrastAAA float32 = 7
+
+	// ContrastAAALarge is the minimum WCAG 2 contrast ratio of large
+	// text with its background for conformance level AAA.
+	ContrastAAALarge float32 = 4.5
+)
+
+const (
+	// APCABody is the minimum APCA lightness contrast (see [APCA]) of
+	// body text (16px at a weight of 400, or 14px at a weight of 700).
+	APCABody float32 = 75
+
+	// APCAContent is the minimum APCA lightness contrast of text that
+	// is not body text (24px at a weight of 400, or 16px at a weight of 700).
+	APCAContent float32 = 60
+
+	// APCALarge is the minimum APCA lightness contrast of large text,
+	// such as headlines (36px at a weight of 400, or 24px at a weight of 700).
+	APCALarge float32 = 45
+
+	// APCASpot is the minimum APCA lightness contrast of text that does not
+	// need to be read, such as placeholder and disabled text, and of
+	// non-text elements, such as icons and outlines.
+	APCASpot float32 = 30
+)
+
+// RelativeLuminance returns the WCAG 2 relative luminance [0..1] of the
+// given color, which is the luminance of its linear sRGB components.
+// The alpha value of the color is ignored.
+func RelativeLuminance(c color.Color) float32 {
+	f := NRGBAF32Model.Convert(c).(NRGBAF32)
+	r, g, b := cie.SRGBToLinear(f.R, f.G, f.B)
+	return 0.2126*r + 0.7152*g + 0.0722*b
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio [1..21] between the two
+// given colors, which is (L1 + 0.05) / (L2 + 0.05), where L1 and L2 are the
+// relative luminances (see [RelativeLuminance]) of the lighter and darker
+// color, respectively. The order of the colors does not matter. See
+// [ContrastAA] and the related constants for the minimum ratios for text.
+// The alpha values of the colors are ignored. It is the same as
+// [hct.ContrastRatio], which computes the luminances from the tones of the
+// colors in the HCT colorspace.
+func ContrastRatio(x, y color.Color) float32 {
+	return hct.ContrastRatio(x, y)
+}
+
+// the constants of APCA 0.0.98G-4g
+const (
+	apcaNormBG      = 0.56
+	apcaNormText    = 0.57
+	apcaRevBG       = 0.65
+	apcaRevText     = 0.62
+	apcaBlackThresh = 0.022
+	apcaBlackClamp  = 1.414
+	apcaScale       = 1.14
+	apcaOffset      = 0.027
+	apcaDeltaYMin   = 0.0005
+	apcaLowClip     = 0.1
+)
+
+// APCA returns the lightness contrast (Lc) [-108..106] of the given text
+// color on the given background color, using the Accessible Perceptual
+// Contrast Algorithm (APCA 0.0.98G-4g) that is proposed for WCAG 3
+// (https://github.com/Myndex/apca-w3). Unlike [ContrastRatio], the order
+// of the colors matters, and it is more accurate for dark colors. It is
+// positive for dark text on a light background and negative for light text
+// on a dark background, and it is 0 if the contrast is very low. See
+// [APCABody] and the related constants for the minimum absolute values
+// for text. The alpha values of the colors are ignored.
+func APCA(text, bg color.Color) float32 {
+	yt, yb := apcaY(text), apcaY(bg)
+	if mat32.Abs(yb-yt) < apcaDeltaYMin {
+		return 0
+	}
+	if yb > yt {
+		// dark text on a light background
+		sapc := (mat32.Pow(yb, apcaNormBG) - mat32.Pow(yt, apcaNormText)) * apcaScale
+		if sapc < apcaLowClip {
+			return 0
+		}
+		return (sapc - apcaOffset) * 100
+	}
+	// light text on a dark background
+	sapc := (mat32.Pow(yb, apcaRevBG) - mat32.Pow(yt, apcaRevText)) * apcaScale
+	if sapc > -apcaLowClip {
+		return 0
+	}
+	return (sapc + apcaOffset) * 100
+}
+
+// apcaY returns the estimated screen luminance of the given color used in
+// [APCA], with a soft clamp of very dark colors to account for flare.
+func apcaY(c color.Color) float32 {
+	f := NRGBAF32Model.Convert(c).(NRGBAF32)
+	y := 0.2126729*mat32.Pow(f.R, 2.4) + 0.7151522*mat32.Pow(f.G, 2.4) + 0.0721750*mat32.Pow(f.B, 2.4)
+	if y < apcaBlackThresh {
+		y += mat32.Pow(apcaBlackThresh-y, apcaBlackClamp)
+	}
+	return y
+}
+
+// ContrastForeground returns a foreground color with the same hue and chroma
+// as the given foreground color, with its tone in the HCT colorspace adjusted
+// as little as possible such that its WCAG 2 contrast ratio (see [ContrastRatio])
+// with the given background color is at least the given ratio (see [ContrastAA]
+// and the related constants). It returns the given foreground color if it already
+// meets the ratio. It keeps the foreground lighter or darker than the background
+// if possible, and it returns the color with the highest contrast if the ratio
+// can not be met.
+func ContrastForeground(fg, bg color.Color, ratio float32) color.RGBA {
+	return foreground(fg, bg, ratio, func(c color.RGBA) float32 {
+		return ContrastRatio(c, bg)
+	})
+}
+
+// APCAForeground returns a foreground color with the same hue and chroma as
+// the given foreground color, with its tone in the HCT colorspace adjusted as
+// little as possible such that the absolute value of its APCA lightness contrast
+// (see [APCA]) on the given background color is at least the given value (see
+// [APCABody] and the related constants). It returns the given foreground color
+// if it already meets the contrast. It keeps the foreground lighter or darker
+// than the background if possible, and it returns the color with the highest
+// contrast if the contrast can not be met.
+func APCAForeground(fg, bg color.Color, lc float32) color.RGBA {
+	return foreground(fg, bg, lc, func(c color.RGBA) float32 {
+		return mat32.Abs(APCA(c, bg))
+	})
+}
+
+// foreground returns the given foreground color with its tone in HCT adjusted
+// as little as possible such that the given contrast function with the
+// background is at least the given target, for [ContrastForeground] and
+// [APCAForeground]. The contrast functions increase with the distance of the
+// tone from the tone of the background, so each side of the background is
+// searched with bisection.
+func foreground(fg, bg color.Color, target float32, contrast func(c color.RGBA) float32) color.RGBA {
+	c := AsRGBA(fg)
+	if contrast(c) >= target {
+		return c
+	}
+	h := hct.FromColor(fg)
+	bt := hct.FromColor(bg).Tone
+	// the starting tone and the extreme tone on each side of the background,
+	// with the side that the foreground is already on first
+	sides := [][2]float32{{mat32.Max(h.Tone, bt), 100}, {mat32.Min(h.Tone, bt), 0}}
+	if h.Tone < bt {
+		sides[0], sides[1] = sides[1], sides[0]
+	}
+	best := c
+	bestContrast := contrast(c)
+	for _, side := range sides {
+		near, far := side[0], side[1]
+		fc := h.WithTone(far).AsRGBA()
+		if fcc := contrast(fc); fcc < target {
+			if fcc > bestContrast {
+				best, bestContrast = fc, fcc
+			}
+			continue
+		}
+		// near does not meet the target and far does
+		for i := 0; i < 16; i++ {
+			mid := (near + far) / 2
+			if contrast(h.WithTone(mid).AsRGBA()) >= target {
+				far = mid
+			} else {
+				near = mid
+			}
+		}
+		return h.WithTone(far).AsRGBA()
+	}
+	return best
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colors
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/cam/hct"
+	"goki.dev/mat32/v2"
+)
+
+func TestContrastRatio(t *testing.T) {
+	for _, tc := range []struct {
+		x, y color.RGBA
+		want float32
+	}{
+		{Black, White, 21},
+		{White, Black, 21},
+		{White, White, 1},
+		{color.RGBA{0x77, 0x77, 0x77, 255}, White, 4.48},
+		{color.RGBA{0x76, 0x76, 0x76, 255}, White, 4.54},
+		{color.RGBA{255, 0, 0, 255}, White, 4},
+		{color.RGBA{0, 0, 255, 255}, Black, 2.44},
+	} {
+		if have := ContrastRatio(tc.x, tc.y); mat32.Abs(have-tc.want) > 0.01 {
+			t.Errorf("expected contrast ratio %g for %v and %v but got %g", tc.want, tc.x, tc.y, have)
+		}
+	}
+	// the alpha values are ignored
+	if have := ContrastRatio(color.NRGBA{255, 255, 255, 128}, color.NRGBA{0, 0, 0, 64}); mat32.Abs(have-21) > 0.01 {
+		t.Errorf("expected contrast ratio 21 for translucent white and black but got %g", have)
+	}
+	if have := RelativeLuminance(color.RGBA{0x80, 0x80, 0x80, 255}); mat32.Abs(have-0.2159) > 0.001 {
+		t.Errorf("expected relative luminance 0.2159 but got %g", have)
+	}
+}
+
+// the reference values are from the tests of apca-w3
+func TestAPCA(t *testing.T) {
+	for _, tc := range []struct {
+		text, bg color.RGBA
+		want     float32
+	}{
+		{color.RGBA{0x88, 0x88, 0x88, 255}, White, 63.056},
+		{White, color.RGBA{0x88, 0x88, 0x88, 255}, -68.541},
+		{Black, color.RGBA{0xaa, 0xaa, 0xaa, 255}, 58.146},
+		{color.RGBA{0xaa, 0xaa, 0xaa, 255}, Black, -56.241},
+		{color.RGBA{0x11, 0x22, 0x33, 255}, color.RGBA{0xdd, 0xee, 0xff, 255}, 91.666},
+		{color.RGBA{0xdd, 0xee, 0xff, 255}, color.RGBA{0x11, 0x22, 0x33, 255}, -93.066},
+		{White, White, 0},
+	} {
+		if have := APCA(tc.text, tc.bg); mat32.Abs(have-tc.want) > 0.01 {
+			t.Errorf("expected APCA %g for %v on %v but got %g", tc.want, tc.text, tc.bg, have)
+		}
+	}
+}
+
+func TestContrastForeground(t *testing.T) {
+	blue := color.RGBA{0x33, 0x66, 0xcc, 255}
+	for _, bg := range []color.RGBA{White, Black, {0x88, 0x88, 0x88, 255}, {0xee, 0xdd, 0x99, 255}, {0x22, 0x11, 0x33, 255}} {
+		fg := ContrastForeground(blue, bg, ContrastAA)
+		if r := ContrastRatio(fg, bg); r < ContrastAA && fg != White && fg != Black {
+			t.Errorf("expected contrast ratio of at least %g for %v on %v but got %g", ContrastAA, fg, bg, r)
+		}
+		// the hue stays the same for colors with chroma
+		if h, bh := hct.FromColor(fg), hct.FromColor(blue); h.Chroma > 10 && mat32.Abs(hct.MinHueDistance(h.Hue, bh.Hue)) > 2 {
+			t.Errorf("expected hue %g for %v on %v but got %g", bh.Hue, fg, bg, h.Hue)
+		}
+
+		fg = APCAForeground(blue, bg, APCABody)
+		if lc := mat32.Abs(APCA(fg, bg)); lc < APCABody && fg != White && fg != Black {
+			t.Errorf("expected APCA of at least %g for %v on %v but got %g", APCABody, fg, bg, lc)
+		}
+	}
+	// colors that already meet the contrast are not changed
+	if fg := ContrastForeground(blue, White, ContrastAA); fg != blue {
+		t.Errorf("expected %v but got %v", blue, fg)
+	}
+	// the foreground stays darker than a light background
+	if fg := ContrastForeground(blue, White, ContrastAAA); hct.FromColor(fg).Tone >= hct.FromColor(blue).Tone {
+		t.Errorf("expected a darker color than %v but got %v", blue, fg)
+	}
+	// it is only slightly past the target
+	if fg := ContrastForeground(blue, White, ContrastAAA); ContrastRatio(fg, White) > ContrastAAA+0.2 {
+		t.Errorf("expected a contrast ratio close to %g but got %g", ContrastAAA, ContrastRatio(fg, White))
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colors
+
+import (
+	"image/color"
+
+	"goki.dev/cam/cam16"
+	"goki.dev/mat32/v2"
+)
+
+// DeltaE returns the perceptual color difference (ΔE) between the two
+// given colors in the CAM16-UCS colorspace, using the scaling of
+// Li et al. (2017), in which a difference of about 1 is barely noticeable.
+// The alpha values of the colors are ignored.
+func DeltaE(x, y color.Color) float32 {
+	fx := NRGBAF32Model.Convert(x).(NRGBAF32)
+	fy := NRGBAF32Model.Convert(y).(NRGBAF32)
+
+	xj, _, xa, xb := cam16.FromSRGB(fx.R, fx.G, fx.B).UCS()
+	yj, _, ya, yb := cam16.FromSRGB(fy.R, fy.G, fy.B).UCS()
+
+	dj, da, db := xj-yj, xa-ya, xb-yb
+	d := mat32.Sqrt(dj*dj + da*da + db*db)
+	return 1.41 * mat32.Pow(d, 0.63)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colors
+
+import "goki.dev/mat32/v2"
+
+// EasingFunc is a function that maps a linear animation progress value from
+// 0 to 1 onto an eased progress value, which is typically also from 0 to 1.
+// Easing functions are used to make color transitions (see [InterpolateScheme]
+// and [goki.dev/colors/gradient.Interpolate]) look more natural; the eased
+// progress value should be passed to those functions.
+type EasingFunc func(t float32) float32
+
+// EaseLinear is an [EasingFunc] that does not change the progress value.
+func EaseLinear(t float32) float32 {
+	return t
+}
+
+// EaseIn is an [EasingFunc] that starts slowly and then accelerates (cubic).
+func EaseIn(t float32) float32 {
+	return t * t * t
+}
+
+// EaseOut is an [EasingFunc] that starts quickly and then decelerates (cubic).
+func EaseOut(t float32) float32 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
+// EaseInOut is an [EasingFunc] that starts slowly, accelerates in the middle,
+// and then decelerates at the end (cubic).
+func EaseInOut(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = -2*t + 2
+	return 1 - t*t*t/2
+}
+
+// CubicBezier returns an [EasingFunc] defined by a cubic Bézier curve from
+// (0, 0) to (1, 1) with the given two control points, as in the CSS
+// cubic-bezier() easing function. The x values must be between 0 and 1.
+func CubicBezier(x1, y1, x2, y2 float32) EasingFunc {
+	bez := func(t, p1, p2 float32) float32 {
+		mt := 1 - t
+		return 3*mt*mt*t*p1 + 3*mt*t*t*p2 + t*t*t
+	}
+	return func(x float32) float32 {
+		if x <= 0 || x >= 1 {
+			return x
+		}
+		// x is monotonic in t for valid control points, so we can bisect
+		lo, hi := float32(0), float32(1)
+		t := x
+		for i := 0; i < 32; i++ {
+			bx := bez(t, x1, x2)
+			if mat32.Abs(bx-x) < 1e-6 {
+				break
+			}
+			if bx < x {
+				lo = t
+			} else {
+				hi = t
+			}
+			t = (lo + hi) / 2
+		}
+		return bez(t, y1, y2)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colors
+
+import (
+	"testing"
+
+	"goki.dev/mat32/v2"
+)
+
+func TestEasing(t *testing.T) {
+	fs := map[string]EasingFunc{
+		"linear":     EaseLinear,
+		"in":         EaseIn,
+		"out":        EaseOut,
+		"in-out":     EaseInOut,
+		"ease (css)": CubicBezier(0.25, 0.1, 0.25, 1),
+	}
+	for nm, f := range fs {
+		if f(0) != 0 || mat32.Abs(f(1)-1) > 1e-5 {
+			t.Errorf("%s: expected f(0) = 0 and f(1) = 1 but got %g and %g", nm, f(0), f(1))
+		}
+		prev := float32(0)
+		for i := 1; i <= 10; i++ {
+			v := f(float32(i) / 10)
+			if v < prev {
+				t.Errorf("%s: expected monotonic values but got %g after %g", nm, v, prev)
+			}
+			prev = v
+		}
+	}
+	if v := CubicBezier(0, 0, 1, 1)(0.3); mat32.Abs(v-0.3) > 1e-4 {
+		t.Errorf("expected linear cubic bezier to return 0.3 but got %g", v)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"errors"
+	"fmt"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+var (
+	// ErrIndexSize is the error returned by the canvas gradient functions
+	// for out of range values, corresponding to the IndexSizeError
+	// DOMException in the HTML Canvas 2D API.
+	ErrIndexSize = errors.New("IndexSizeError")
+
+	// ErrSyntax is the error returned by [CanvasGradient.AddColorStop] for
+	// colors that can not be parsed, corresponding to the SyntaxError
+	// DOMException in the HTML Canvas 2D API.
+	ErrSyntax = errors.New("SyntaxError")
+)
+
+// CanvasGradient is a gradient created through the HTML Canvas 2D API
+// style functions [CreateLinearGradient], [CreateRadialGradient], and
+// [CreateConicGradient]. It wraps a [Linear], [Radial], or [Conic] gradient
+// specified in [UserSpaceOnUse] units, and it adds color stops following
+// the semantics of the canvas addColorStop method.
+// See https://developer.mozilla.org/en-US/docs/Web/API/CanvasGradient
+type CanvasGradient struct {
+
+	// Gradient is the underlying gradient, which should be used as the
+	// image when rendering.
+	Gradient Gradient
+
+	// offStart and offScale map a canvas offset onto a stop position,
+	// which is needed to represent the starting radius of radial gradients.
+	offStart, offScale float32
+}
+
+// CreateLinearGradient returns a new [CanvasGradient] with a [Linear] gradient
+// along the line from (x0, y0) to (x1, y1) in user space, as in the
+// createLinearGradient method of the HTML Canvas 2D API.
+func CreateLinearGradient(x0, y0, x1, y1 float32) *CanvasGradient {
+	l := NewLinear()
+	l.SetUnits(UserSpaceOnUse)
+	l.Start.Set(x0, y0)
+	l.End.Set(x1, y1)
+	return &CanvasGradient{Gradient: l, offScale: 1}
+}
+
+// CreateRadialGradient returns a new [CanvasGradient] with a [Radial] gradient
+// between the circle at (x0, y0) with radius r0 and the circle at (x1, y1)
+// with radius r1 in user space, as in the createRadialGradient method of the
+// HTML Canvas 2D API. It returns an error wrapping [ErrIndexSize] if either
+// radius is negative.
+//
+// The starting circle is represented by the focal point of the gradient, and
+// its radius by offsetting the color stops, which only matches the two-circle
+// model of the canvas API for concentric circles with r0 < r1 and for a
+// starting circle with r0 = 0 whose center is inside of the ending circle.
+// It returns an error wrapping [errors.ErrUnsupported] for all other circles.
+func CreateRadialGradient(x0, y0, r0, x1, y1, r1 float32) (*CanvasGradient, error) {
+	if r0 < 0 || r1 < 0 {
+		return nil, fmt.Errorf("gradient.CreateRadialGradient: radii must not be negative, but got %g and %g: %w", r0, r1, ErrIndexSize)
+	}
+	concentric := x0 == x1 && y0 == y1
+	if (concentric && r0 >= r1) || (!concentric && (r0 != 0 || mat32.Hypot(x1-x0, y1-y0) >= r1)) {
+		return nil, fmt.Errorf("gradient.CreateRadialGradient: circles at (%g, %g) with radius %g and at (%g, %g) with radius %g must be concentric with r0 < r1 or have r0 = 0 with the starting center inside of the ending circle: %w", x0, y0, r0, x1, y1, r1, errors.ErrUnsupported)
+	}
+	r := NewRadial()
+	r.SetUnits(UserSpaceOnUse)
+	r.Center.Set(x1, y1)
+	r.Focal.Set(x0, y0)
+	r.Radius.SetScalar(r1)
+	cg := &CanvasGradient{Gradient: r, offStart: r0 / r1}
+	cg.offScale = 1 - cg.offStart
+	return cg, nil
+}
+
+// CreateConicGradient returns a new [CanvasGradient] with a [Conic] gradient
+// around the point (x, y) in user space starting at the given angle in radians,
+// as in the createConicGradient method of the HTML Canvas 2D API.
+func CreateConicGradient(angle, x, y float32) *CanvasGradient {
+	c := NewConic()
+	c.SetUnits(UserSpaceOnUse)
+	c.Center.Set(x, y)
+	c.Angle = mat32.RadToDeg(angle)
+	return &CanvasGradient{Gradient: c, offScale: 1}
+}
+
+// AddColorStop adds a new color stop with the given offset and CSS color string
+// to the gradient, as in the addColorStop method of the HTML Canvas 2D API.
+// It returns an error wrapping [ErrIndexSize] if the offset is outside of the
+// range 0 to 1, and an error wrapping [ErrSyntax] if the color can not be parsed.
+// Stops added at the same offset as existing stops are placed after them, so
+// that they can be used to create sharp transitions.
+func (cg *CanvasGradient) AddColorStop(offset float32, clr string) error {
+	if mat32.IsNaN(offset) || offset < 0 || offset > 1 {
+		return fmt.Errorf("gradient.CanvasGradient.AddColorStop: offset %g is outside of the range 0 to 1: %w", offset, ErrIndexSize)
+	}
+	c, err := colors.FromString(clr)
+	if err != nil {
+		return fmt.Errorf("gradient.CanvasGradient.AddColorStop: invalid color %q: %w: %w", clr, ErrSyntax, err)
+	}
+	pos := cg.offStart + offset*cg.offScale
+	gb := cg.Gradient.AsBase()
+	idx := len(gb.Stops)
+	for i, s := range gb.Stops {
+		if s.Pos > pos {
+			idx = i
+			break
+		}
+	}
+	gb.Stops = append(gb.Stops, Stop{})
+	copy(gb.Stops[idx+1:], gb.Stops[idx:])
+	gb.Stops[idx] = Stop{Color: c, Pos: pos}
+	return nil
+}
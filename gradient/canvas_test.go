@@ -0,0 +1,109 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"errors"
+	"image/color"
+	"testing"
+
+	"goki.dev/colors"
+)
+
+func TestCanvasAddColorStop(t *testing.T) {
+	cg := CreateLinearGradient(0, 0, 100, 0)
+	for _, off := range []float32{-0.1, 1.5} {
+		if err := cg.AddColorStop(off, "red"); !errors.Is(err, ErrIndexSize) {
+			t.Errorf("expected IndexSizeError for offset %g but got %v", off, err)
+		}
+	}
+	if err := cg.AddColorStop(0.5, "notacolor"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("expected SyntaxError for invalid color but got %v", err)
+	}
+
+	// stops at the same offset are placed in the order they are added
+	cg.AddColorStop(1, "blue")
+	cg.AddColorStop(0.5, "red")
+	cg.AddColorStop(0, "white")
+	cg.AddColorStop(0.5, "green")
+	want := []Stop{{colors.White, 0}, {colors.Red, 0.5}, {colors.Green, 0.5}, {colors.Blue, 1}}
+	have := cg.Gradient.AsBase().Stops
+	if len(have) != len(want) {
+		t.Fatalf("expected %d stops but got %d", len(want), len(have))
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Errorf("stop %d: expected %v but got %v", i, want[i], have[i])
+		}
+	}
+
+	cg.Gradient.Update()
+	if c := colors.AsRGBA(cg.Gradient.At(49, 10)); c.G > 5 {
+		t.Errorf("expected red before the sharp transition but got %v", c)
+	}
+	if c := colors.AsRGBA(cg.Gradient.At(50, 10)); c.R > 5 {
+		t.Errorf("expected green after the sharp transition but got %v", c)
+	}
+}
+
+func TestCanvasRadial(t *testing.T) {
+	if _, err := CreateRadialGradient(50, 50, -1, 50, 50, 40); !errors.Is(err, ErrIndexSize) {
+		t.Errorf("expected IndexSizeError for negative radius but got %v", err)
+	}
+	for _, c := range [][6]float32{{50, 50, 40, 50, 50, 20}, {50, 50, 20, 50, 50, 20}, {40, 50, 10, 50, 50, 40}, {0, 50, 0, 50, 50, 40}} {
+		if _, err := CreateRadialGradient(c[0], c[1], c[2], c[3], c[4], c[5]); !errors.Is(err, errors.ErrUnsupported) {
+			t.Errorf("expected unsupported error for circles %v but got %v", c, err)
+		}
+	}
+	cg, err := CreateRadialGradient(50, 50, 20, 50, 50, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cg.AddColorStop(0, "blue")
+	cg.AddColorStop(1, "yellow")
+	cg.Gradient.Update()
+	if c := cg.Gradient.At(60, 50); c != colors.Blue {
+		t.Errorf("expected blue inside of the starting circle but got %v", c)
+	}
+	if c := cg.Gradient.At(95, 50); c != colors.Yellow {
+		t.Errorf("expected yellow outside of the ending circle but got %v", c)
+	}
+
+	// a starting point inside of the ending circle
+	cg, err = CreateRadialGradient(30, 50, 0, 50, 50, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cg.AddColorStop(0, "black")
+	cg.AddColorStop(1, "white")
+	cg.Gradient.Update()
+	// halfway between the starting point and the ending circle on either side
+	for _, x := range []int{19, 59} {
+		if c := cg.Gradient.At(x, 50).(color.RGBA); c.R < 120 || c.R > 136 {
+			t.Errorf("expected gray at (%d, 50) but got %v", x, c)
+		}
+	}
+}
+
+func TestCanvasConic(t *testing.T) {
+	cg := CreateConicGradient(0, 50, 50)
+	cg.AddColorStop(0, "black")
+	cg.AddColorStop(1, "white")
+	cg.Gradient.Update()
+	type value struct {
+		x, y int
+		want color.RGBA
+	}
+	// angles increase clockwise from the right of the center
+	for _, v := range []value{
+		{80, 50, color.RGBA{0, 0, 0, 255}},
+		{49, 80, color.RGBA{64, 64, 64, 255}},
+		{20, 49, color.RGBA{128, 128, 128, 255}},
+	} {
+		if c := cg.Gradient.At(v.x, v.y); c != v.want {
+			t.Errorf("expected %v at (%d, %d) but got %v", v.want, v.x, v.y, c)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"slices"
+
+	"goki.dev/colors"
+)
+
+// Equal returns whether the two given gradients are equal, which is the case if
+// they are of the same type and have the same stops, spread method, blend type,
+// units, transform, and geometry (for example, [Linear.Start] and [Linear.End]).
+// The bounding box and the computed fields set by [Gradient.Update] are not
+// compared, as they depend on the object that the gradient is rendered on.
+func Equal(a, b Gradient) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	switch a := a.(type) {
+	case *Linear:
+		b, ok := b.(*Linear)
+		return ok && a.Start == b.Start && a.End == b.End && a.Base.Equal(&b.Base)
+	case *Radial:
+		b, ok := b.(*Radial)
+		return ok && a.Center == b.Center && a.Focal == b.Focal && a.Radius == b.Radius && a.Base.Equal(&b.Base)
+	case *Conic:
+		b, ok := b.(*Conic)
+		return ok && a.Center == b.Center && a.Angle == b.Angle && a.Base.Equal(&b.Base)
+	}
+	return false
+}
+
+// Equal returns whether the given base gradient has the same stops, spread method,
+// blend type, units, and transform as this base gradient. See [Equal] for comparing
+// full gradients.
+func (b *Base) Equal(o *Base) bool {
+	return b.Spread == o.Spread && b.Blend == o.Blend && b.Units == o.Units &&
+		b.Transform == o.Transform && slices.Equal(b.Stops, o.Stops)
+}
+
+// Hash returns a stable hash of the given gradient based on all of the
+// values compared by [Equal], such that equal gradients always have the
+// same hash. It is useful for deduplicating gradients, for example
+// as the key of a map.
+func Hash(g Gradient) uint64 {
+	h := fnv.New64a()
+	var buf []byte
+	f32 := func(vs ...float32) {
+		for _, v := range vs {
+			if v == 0 { // so that 0 and -0 have the same hash
+				v = 0
+			}
+			buf = binary.LittleEndian.AppendUint32(buf, math.Float32bits(v))
+		}
+	}
+	switch g := g.(type) {
+	case *Linear:
+		buf = append(buf, 'l')
+		f32(g.Start.X, g.Start.Y, g.End.X, g.End.Y)
+	case *Radial:
+		buf = append(buf, 'r')
+		f32(g.Center.X, g.Center.Y, g.Focal.X, g.Focal.Y, g.Radius.X, g.Radius.Y)
+	case *Conic:
+		buf = append(buf, 'c')
+		f32(g.Center.X, g.Center.Y, g.Angle)
+	default:
+		return 0
+	}
+	gb := g.AsBase()
+	buf = append(buf, byte(gb.Spread), byte(gb.Blend), byte(gb.Units))
+	tf := gb.Transform
+	f32(tf.XX, tf.YX, tf.XY, tf.YY, tf.X0, tf.Y0)
+	for _, s := range gb.Stops {
+		buf = append(buf, s.Color.R, s.Color.G, s.Color.B, s.Color.A)
+		f32(s.Pos)
+	}
+	h.Write(buf)
+	return h.Sum64()
+}
+
+// Difference returns the maximum and mean perceptual difference (see
+// [colors.DeltaE]) between the colors of the two given gradients at the
+// given number of evenly spaced positions from 0 to 1 along their stops.
+// It only compares the colors of the gradients, not their geometry; see
+// [Equal] for that. It returns 0, 0 if either gradient has no stops.
+func Difference(a, b Gradient, n int) (maxDE, meanDE float32) {
+	ab, bb := a.AsBase(), b.AsBase()
+	if len(ab.Stops) == 0 || len(bb.Stops) == 0 {
+		return 0, 0
+	}
+	n = max(n, 2)
+	for i := 0; i < n; i++ {
+		pos := float32(i) / float32(n-1)
+		de := colors.DeltaE(ab.PadColor(pos), bb.PadColor(pos))
+		maxDE = max(maxDE, de)
+		meanDE += de
+	}
+	meanDE /= float32(n)
+	return
+}
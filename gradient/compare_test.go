@@ -0,0 +1,56 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestEqualHash(t *testing.T) {
+	a := NewLinear().AddStop(colors.White, 0).AddStop(colors.Black, 1)
+	b := CopyOf(a).(*Linear)
+	b.SetBox(mat32.B2(10, 10, 20, 20))
+	b.Update()
+	if !Equal(a, b) {
+		t.Errorf("expected gradients to be equal")
+	}
+	if Hash(a) != Hash(b) {
+		t.Errorf("expected equal gradients to have the same hash")
+	}
+
+	b.End.Set(0, 1)
+	if Equal(a, b) || Hash(a) == Hash(b) {
+		t.Errorf("expected gradients with different geometry to differ")
+	}
+
+	r := NewRadial().AddStop(colors.White, 0).AddStop(colors.Black, 1)
+	if Equal(a, r) || Hash(a) == Hash(r) {
+		t.Errorf("expected gradients of different types to differ")
+	}
+
+	c := CopyOf(a).(*Linear)
+	c.Stops[1].Color = colors.Gray
+	if Equal(a, c) || Hash(a) == Hash(c) {
+		t.Errorf("expected gradients with different stops to differ")
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a := NewLinear().AddStop(colors.White, 0).AddStop(colors.Black, 1)
+	if mx, mn := Difference(a, CopyOf(a), 16); mx != 0 || mn != 0 {
+		t.Errorf("expected no difference but got %g, %g", mx, mn)
+	}
+	b := NewLinear().AddStop(colors.White, 0).AddStop(colors.Red, 1)
+	mx, mn := Difference(a, b, 16)
+	if mx <= mn || mn <= 0 {
+		t.Errorf("expected max difference %g to be greater than mean difference %g > 0", mx, mn)
+	}
+	if want := colors.DeltaE(colors.Black, colors.Red); mat32.Abs(mx-want) > 1e-4 {
+		t.Errorf("expected max difference %g but got %g", want, mx)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"image/color"
+
+	"goki.dev/mat32/v2"
+)
+
+// Conic represents a conic (sweep) gradient, in which the colors
+// sweep around a center point. It implements the [image.Image] interface.
+type Conic struct { //gti:add -setters
+	Base
+
+	// the center point of the gradient
+	Center mat32.Vec2
+
+	// the starting angle of the gradient in degrees, measured clockwise
+	// from the positive x axis (with y pointing down)
+	Angle float32
+
+	// EffCenter is the computed effective transformed center point of the gradient.
+	// It should not be set by end users.
+	EffCenter mat32.Vec2 `set:"-"`
+
+	// EffAngle is the computed effective transformed starting angle of the gradient
+	// in radians. It should not be set by end users.
+	EffAngle float32 `set:"-"`
+}
+
+var _ Gradient = &Conic{}
+
+// NewConic returns a new centered [Conic] gradient.
+func NewConic() *Conic {
+	return &Conic{
+		Base:   NewBase(),
+		Center: mat32.V2Scalar(0.5),
+	}
+}
+
+// AddStop adds a new stop with the given color and position to the conic gradient.
+func (c *Conic) AddStop(color color.RGBA, pos float32) *Conic {
+	c.Base.AddStop(color, pos)
+	return c
+}
+
+// Update updates the computed fields of the gradient. It must be
+// called before rendering the gradient, and it should only be called then.
+func (c *Conic) Update() {
+	c.UpdateBase()
+
+	rad := mat32.DegToRad(c.Angle)
+	if c.Units == ObjectBoundingBox {
+		c.EffCenter = c.Box.Min.Add(c.Box.Size().Mul(c.Center))
+		c.EffAngle = rad
+	} else {
+		c.EffCenter = c.Transform.MulVec2AsPt(c.Center)
+		dir := c.Transform.MulVec2AsVec(mat32.V2(mat32.Cos(rad), mat32.Sin(rad)))
+		c.EffAngle = mat32.Atan2(dir.Y, dir.X)
+	}
+}
+
+// At returns the color of the conic gradient at the given point
+func (c *Conic) At(x, y int) color.Color {
+	switch len(c.Stops) {
+	case 0:
+		return color.RGBA{}
+	case 1:
+		return c.Stops[0].Color
+	}
+
+	pt := mat32.V2(float32(x)+0.5, float32(y)+0.5)
+	if c.Units == ObjectBoundingBox {
+		pt = c.ObjectMatrix.MulVec2AsPt(pt)
+	}
+	d := pt.Sub(c.EffCenter)
+	pos := (mat32.Atan2(d.Y, d.X) - c.EffAngle) / (2 * mat32.Pi)
+	pos -= mat32.Floor(pos) // always sweep from 0 to 1
+	return c.GetColor(pos)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"image/color"
+	"slices"
+	"sort"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+// Reverse reverses the direction of the gradient stops,
+// such that the stop at position p moves to position 1-p.
+func (b *Base) Reverse() {
+	slices.Reverse(b.Stops)
+	for i := range b.Stops {
+		b.Stops[i].Pos = 1 - b.Stops[i].Pos
+	}
+}
+
+// NormalizeStops sorts the gradient stops by position, keeping the
+// existing order of stops at the same position, and then linearly
+// rescales the stop positions such that the first stop is at 0
+// and the last stop is at 1.
+func (b *Base) NormalizeStops() {
+	sort.SliceStable(b.Stops, func(i, j int) bool {
+		return b.Stops[i].Pos < b.Stops[j].Pos
+	})
+	n := len(b.Stops)
+	if n == 0 {
+		return
+	}
+	lo, hi := b.Stops[0].Pos, b.Stops[n-1].Pos
+	if lo == hi {
+		for i := range b.Stops {
+			b.Stops[i].Pos = 0
+		}
+		if n > 1 {
+			b.Stops[n-1].Pos = 1
+		}
+		return
+	}
+	for i := range b.Stops {
+		b.Stops[i].Pos = (b.Stops[i].Pos - lo) / (hi - lo)
+	}
+}
+
+// InsertStopAt inserts a new stop at the given position with the color that
+// the gradient currently has at that position, such that the appearance of the
+// gradient does not change. It returns the index of the new stop. The stops
+// must be sorted by position, which can be ensured with [Base.NormalizeStops].
+func (b *Base) InsertStopAt(pos float32) int {
+	var clr color.RGBA
+	if len(b.Stops) > 0 {
+		clr = colors.AsRGBA(b.PadColor(pos))
+	}
+	idx := sort.Search(len(b.Stops), func(i int) bool {
+		return b.Stops[i].Pos > pos
+	})
+	b.Stops = slices.Insert(b.Stops, idx, Stop{Color: clr, Pos: pos})
+	return idx
+}
+
+// RemoveStop removes the stop at the given index.
+func (b *Base) RemoveStop(idx int) {
+	b.Stops = slices.Delete(b.Stops, idx, idx+1)
+}
+
+// Resample replaces the gradient stops with the given number of evenly
+// spaced stops from 0 to 1, using the colors that the gradient currently
+// has at those positions. A number of stops less than 2 is treated as 2,
+// and a gradient without stops is left unchanged.
+func (b *Base) Resample(n int) {
+	if len(b.Stops) == 0 {
+		return
+	}
+	n = max(n, 2)
+	stops := make([]Stop, n)
+	for i := range stops {
+		pos := float32(i) / float32(n-1)
+		stops[i] = Stop{Color: colors.AsRGBA(b.PadColor(pos)), Pos: pos}
+	}
+	b.Stops = stops
+}
+
+// Simplify removes all stops that can be removed while keeping the color of
+// the gradient at the positions of the original stops within the given
+// perceptual difference (see [colors.DeltaE]) of their original colors.
+// The first and last stops are always kept. The stops must be sorted by
+// position, which can be ensured with [Base.NormalizeStops].
+func (b *Base) Simplify(tolerance float32) {
+	orig := slices.Clone(b.Stops)
+	if len(orig) < 3 {
+		return
+	}
+	// within returns whether all of the original stops between
+	// the stops at the given indexes are within the tolerance
+	// if the stops between them are removed.
+	within := func(from, to int) bool {
+		s1, s2 := orig[from], orig[to]
+		for _, s := range orig[from+1 : to] {
+			c := b.BlendStops(s.Pos, s1, s2, false)
+			if colors.DeltaE(c, s.Color) > tolerance {
+				return false
+			}
+		}
+		return true
+	}
+	b.Stops = b.Stops[:1]
+	last := 0
+	for i := 1; i < len(orig)-1; i++ {
+		if !within(last, i+1) {
+			b.Stops = append(b.Stops, orig[i])
+			last = i
+		}
+	}
+	b.Stops = append(b.Stops, orig[len(orig)-1])
+}
+
+// PadColor returns the color at the given normalized position along the
+// gradient's stops like [Base.GetColor], except that it always uses the
+// [Pad] spread method, which makes it useful for sampling the stops.
+func (b *Base) PadColor(pos float32) color.Color {
+	pb := *b
+	pb.Spread = Pad
+	return pb.GetColor(pos)
+}
+
+// Rotate rotates the given gradient by the given angle in degrees around its
+// center by updating its [Base.Transform]. The center is the midpoint of
+// [Linear.Start] and [Linear.End] for linear gradients and the center point
+// for radial and conic gradients.
+func Rotate(g Gradient, angle float32) {
+	rot := mat32.Rotate2D(mat32.DegToRad(angle))
+	transformAboutCenter(g, rot.MulVec2AsVec)
+}
+
+// FlipX flips (mirrors) the given gradient horizontally around its center
+// (see [Rotate]) by updating its [Base.Transform].
+func FlipX(g Gradient) {
+	transformAboutCenter(g, func(v mat32.Vec2) mat32.Vec2 {
+		return mat32.V2(-v.X, v.Y)
+	})
+}
+
+// FlipY flips (mirrors) the given gradient vertically around its center
+// (see [Rotate]) by updating its [Base.Transform].
+func FlipY(g Gradient) {
+	transformAboutCenter(g, func(v mat32.Vec2) mat32.Vec2 {
+		return mat32.V2(v.X, -v.Y)
+	})
+}
+
+// transformAboutCenter updates the transform of the given gradient such that
+// the given linear function is applied after the existing transform relative
+// to the transformed center point of the gradient.
+func transformAboutCenter(g Gradient, f func(v mat32.Vec2) mat32.Vec2) {
+	var ctr mat32.Vec2
+	switch g := g.(type) {
+	case *Linear:
+		ctr = g.Start.Add(g.End).MulScalar(0.5)
+	case *Radial:
+		ctr = g.Center
+	case *Conic:
+		ctr = g.Center
+	}
+	gb := g.AsBase()
+	tf := gb.Transform
+	ctr = tf.MulVec2AsPt(ctr)
+	apply := func(p mat32.Vec2) mat32.Vec2 {
+		return f(tf.MulVec2AsPt(p).Sub(ctr)).Add(ctr)
+	}
+	// an affine transform is fully determined by where it maps these three points
+	o := apply(mat32.Vec2{})
+	x := apply(mat32.V2(1, 0)).Sub(o)
+	y := apply(mat32.V2(0, 1)).Sub(o)
+	gb.Transform = mat32.Mat2{XX: x.X, YX: x.Y, XY: y.X, YY: y.Y, X0: o.X, Y0: o.Y}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"reflect"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestReverse(t *testing.T) {
+	l := NewLinear().AddStop(colors.Red, 0).AddStop(colors.Green, 0.25).AddStop(colors.Blue, 1)
+	l.Reverse()
+	want := []Stop{{colors.Blue, 0}, {colors.Green, 0.75}, {colors.Red, 1}}
+	if !reflect.DeepEqual(l.Stops, want) {
+		t.Errorf("expected %v but got %v", want, l.Stops)
+	}
+}
+
+func TestNormalizeStops(t *testing.T) {
+	l := NewLinear().AddStop(colors.Blue, 0.8).AddStop(colors.Red, 0.2).AddStop(colors.Green, 0.5)
+	l.NormalizeStops()
+	want := []Stop{{colors.Red, 0}, {colors.Green, 0.5}, {colors.Blue, 1}}
+	if !reflect.DeepEqual(l.Stops, want) {
+		t.Errorf("expected %v but got %v", want, l.Stops)
+	}
+}
+
+func TestInsertRemoveStop(t *testing.T) {
+	l := NewLinear().AddStop(colors.White, 0).AddStop(colors.Black, 1)
+	want := l.GetColor(0.3)
+	idx := l.InsertStopAt(0.3)
+	if idx != 1 || len(l.Stops) != 3 {
+		t.Fatalf("expected new stop at index 1 of 3 but got %d of %d", idx, len(l.Stops))
+	}
+	if l.Stops[1].Color != want {
+		t.Errorf("expected inserted color %v but got %v", want, l.Stops[1].Color)
+	}
+	l.RemoveStop(1)
+	if len(l.Stops) != 2 || l.Stops[1].Color != colors.Black {
+		t.Errorf("expected stop to be removed but got %v", l.Stops)
+	}
+}
+
+func TestResampleSimplify(t *testing.T) {
+	l := NewLinear().AddStop(colors.White, 0).AddStop(colors.Black, 1)
+	l.Resample(5)
+	if len(l.Stops) != 5 || l.Stops[2].Pos != 0.5 {
+		t.Fatalf("expected 5 evenly spaced stops but got %v", l.Stops)
+	}
+	l2 := NewLinear().AddStop(colors.White, 0).AddStop(colors.Red, 0.5).AddStop(colors.Black, 1)
+	if l2.Resample(1); len(l2.Stops) != 2 || l2.Stops[1].Color != colors.Black {
+		t.Errorf("expected a count of 1 to be treated as 2 but got %v", l2.Stops)
+	}
+	// the resampled stops are all on the line between the ends
+	l.Simplify(1)
+	if len(l.Stops) != 2 {
+		t.Errorf("expected simplification to 2 stops but got %v", l.Stops)
+	}
+
+	l = NewLinear().AddStop(colors.White, 0).AddStop(colors.Red, 0.5).AddStop(colors.Black, 1)
+	l.Simplify(1)
+	if len(l.Stops) != 3 {
+		t.Errorf("expected no simplification but got %v", l.Stops)
+	}
+}
+
+func TestRotateFlip(t *testing.T) {
+	l := NewLinear().SetUnits(UserSpaceOnUse)
+	l.Start, l.End = mat32.V2(0, 50), mat32.V2(100, 50)
+	Rotate(l, 90)
+	l.Update()
+	expectVec(t, mat32.V2(50, 0), l.EffStart)
+	expectVec(t, mat32.V2(50, 100), l.EffEnd)
+
+	FlipY(l)
+	l.Update()
+	expectVec(t, mat32.V2(50, 100), l.EffStart)
+	expectVec(t, mat32.V2(50, 0), l.EffEnd)
+
+	c := NewConic().SetUnits(UserSpaceOnUse)
+	c.Center = mat32.V2(50, 50)
+	FlipX(c)
+	c.Update()
+	expectVec(t, mat32.V2(50, 50), c.EffCenter)
+	if mat32.Abs(mat32.Abs(c.EffAngle)-mat32.Pi) > 1e-5 {
+		t.Errorf("expected flipped angle of pi but got %g", c.EffAngle)
+	}
+}
+
+func expectVec(t *testing.T, want, have mat32.Vec2) {
+	t.Helper()
+	if mat32.Abs(want.X-have.X) > 1e-3 || mat32.Abs(want.Y-have.Y) > 1e-3 {
+		t.Errorf("expected %v but got %v", want, have)
+	}
+}
@@ -115,7 +115,7 @@ func NewBase() Base {
 	return Base{
 		Blend:     colors.RGB, // TODO(kai): figure out a better solution to this
 		Box:       mat32.B2(0, 0, 100, 100),
-		Transform: mat32.Identity2(),
+		Transform: mat32.Identity2D(),
 	}
 }
 
@@ -138,6 +138,8 @@ func CopyFrom(g Gradient, cp Gradient) {
 		*g = *cp.(*Linear)
 	case *Radial:
 		*g = *cp.(*Radial)
+	case *Conic:
+		*g = *cp.(*Conic)
 	}
 	g.AsBase().CopyStopsFrom(cp.AsBase())
 }
@@ -153,6 +155,9 @@ func CopyOf(g Gradient) Gradient {
 	case *Radial:
 		res = &Radial{}
 		CopyFrom(res, g)
+	case *Conic:
+		res = &Conic{}
+		CopyFrom(res, g)
 	}
 	return res
 }
@@ -175,7 +180,7 @@ func (b *Base) UpdateBase() {
 func (b *Base) ComputeObjectMatrix() {
 	w, h := b.Box.Size().X, b.Box.Size().Y
 	oriX, oriY := b.Box.Min.X, b.Box.Min.Y
-	b.ObjectMatrix = mat32.Identity2().Translate(oriX, oriY).Scale(w, h).
+	b.ObjectMatrix = mat32.Identity2D().Translate(oriX, oriY).Scale(w, h).
 		Mul(b.Transform).Scale(1/w, 1/h).Translate(-oriX, -oriY).Inverse()
 }
 
@@ -252,7 +257,8 @@ func (b *Base) GetColor(pos float32) color.Color {
 }
 
 // BlendStops blends the given two gradient stops together based on the given position,
-// using the gradient's blending algorithm. If flip is true, it flips the given position.
+// using the gradient's blending algorithm with premultiplied alpha (see [colors.BlendPremult]).
+// If flip is true, it flips the given position.
 func (b *Base) BlendStops(pos float32, s1, s2 Stop, flip bool) color.Color {
 	s1off := s1.Pos
 	if s1.Pos > s2.Pos && !flip { // happens in repeat spread mode
@@ -269,5 +275,5 @@ func (b *Base) BlendStops(pos float32, s1, s2 Stop, flip bool) color.Color {
 	}
 	tp := (pos - s1off) / (s2.Pos - s1off)
 
-	return colors.Blend(b.Blend, 100*(1-tp), s1.Color, s2.Color)
+	return colors.BlendPremult(b.Blend, 100*(1-tp), s1.Color, s2.Color)
 }
@@ -97,3 +97,17 @@ func TestColorAt(t *testing.T) {
 		}
 	}
 }
+
+func TestTransparentStops(t *testing.T) {
+	for _, bt := range colors.BlendTypesValues() {
+		l := NewLinear().SetBlend(bt).AddStop(colors.Red, 0).AddStop(colors.Transparent, 1)
+		for _, pos := range []float32{0.25, 0.5, 0.75} {
+			c := colors.AsRGBA(l.GetColor(pos))
+			// there should be no gray fringe: the color only fades out
+			n := color.NRGBAModel.Convert(c).(color.NRGBA)
+			if n.R < 250 || n.G > 5 || n.B > 5 {
+				t.Errorf("%v at %g: expected faded red but got %v (%v)", bt, pos, c, n)
+			}
+		}
+	}
+}
@@ -9,6 +9,71 @@ import (
 	"goki.dev/ordmap"
 )
 
+var _ = gti.AddType(&gti.Type{
+	Name:      "goki.dev/colors/gradient.Conic",
+	ShortName: "gradient.Conic",
+	IDName:    "conic",
+	Doc:       "Conic represents a conic (sweep) gradient, in which the colors\nsweep around a center point. It implements the [image.Image] interface.",
+	Directives: gti.Directives{
+		&gti.Directive{Tool: "gti", Directive: "add", Args: []string{"-setters"}},
+	},
+	Fields: ordmap.Make([]ordmap.KeyVal[string, *gti.Field]{
+		{"Center", &gti.Field{Name: "Center", Type: "goki.dev/mat32/v2.Vec2", LocalType: "mat32.Vec2", Doc: "the center point of the gradient", Directives: gti.Directives{}, Tag: ""}},
+		{"Angle", &gti.Field{Name: "Angle", Type: "float32", LocalType: "float32", Doc: "the starting angle of the gradient in degrees, measured clockwise\nfrom the positive x axis (with y pointing down)", Directives: gti.Directives{}, Tag: ""}},
+		{"EffCenter", &gti.Field{Name: "EffCenter", Type: "goki.dev/mat32/v2.Vec2", LocalType: "mat32.Vec2", Doc: "EffCenter is the computed effective transformed center point of the gradient.\nIt should not be set by end users.", Directives: gti.Directives{}, Tag: "set:\"-\""}},
+		{"EffAngle", &gti.Field{Name: "EffAngle", Type: "float32", LocalType: "float32", Doc: "EffAngle is the computed effective transformed starting angle of the gradient\nin radians. It should not be set by end users.", Directives: gti.Directives{}, Tag: "set:\"-\""}},
+	}),
+	Embeds: ordmap.Make([]ordmap.KeyVal[string, *gti.Field]{
+		{"Base", &gti.Field{Name: "Base", Type: "goki.dev/colors/gradient.Base", LocalType: "Base", Doc: "", Directives: gti.Directives{}, Tag: ""}},
+	}),
+	Methods: ordmap.Make([]ordmap.KeyVal[string, *gti.Method]{}),
+})
+
+// SetCenter sets the [Conic.Center]:
+// the center point of the gradient
+func (t *Conic) SetCenter(v mat32.Vec2) *Conic {
+	t.Center = v
+	return t
+}
+
+// SetAngle sets the [Conic.Angle]:
+// the starting angle of the gradient in degrees, measured clockwise
+// from the positive x axis (with y pointing down)
+func (t *Conic) SetAngle(v float32) *Conic {
+	t.Angle = v
+	return t
+}
+
+// SetSpread sets the [Conic.Spread]
+func (t *Conic) SetSpread(v Spreads) *Conic {
+	t.Spread = v
+	return t
+}
+
+// SetBlend sets the [Conic.Blend]
+func (t *Conic) SetBlend(v colors.BlendTypes) *Conic {
+	t.Blend = v
+	return t
+}
+
+// SetUnits sets the [Conic.Units]
+func (t *Conic) SetUnits(v Units) *Conic {
+	t.Units = v
+	return t
+}
+
+// SetBox sets the [Conic.Box]
+func (t *Conic) SetBox(v mat32.Box2) *Conic {
+	t.Box = v
+	return t
+}
+
+// SetTransform sets the [Conic.Transform]
+func (t *Conic) SetTransform(v mat32.Mat2) *Conic {
+	t.Transform = v
+	return t
+}
+
 var _ = gti.AddType(&gti.Type{
 	Name:      "goki.dev/colors/gradient.Base",
 	ShortName: "gradient.Base",
@@ -0,0 +1,117 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"image/color"
+	"slices"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+// Interpolate returns a new gradient that is the given proportion t (from 0 to 1)
+// of the way between the two given gradients. The stops of the gradients are
+// matched by sampling both gradients at the positions of all of their stops,
+// and hard stops (multiple stops at the same position) in either gradient are
+// kept by sampling the colors just below and just above their position.
+// The colors are blended using the given blending algorithm with
+// premultiplied alpha (see [colors.BlendPremult]). If the gradients
+// are of the same type, their geometry and transform are also interpolated;
+// otherwise, the result has the type and geometry of the gradient that t is
+// closest to, as do the spread method, blend type, and units. It is useful for
+// animating transitions between gradients, in which case the progress value
+// can first be passed through a [colors.EasingFunc].
+func Interpolate(bt colors.BlendTypes, a, b Gradient, t float32) Gradient {
+	near := a
+	if t >= 0.5 {
+		near = b
+	}
+	res := CopyOf(near)
+	ab, bb, rb := a.AsBase(), b.AsBase(), res.AsBase()
+
+	if len(ab.Stops) > 0 && len(bb.Stops) > 0 {
+		var poss []float32
+		for _, s := range ab.Stops {
+			poss = append(poss, s.Pos)
+		}
+		for _, s := range bb.Stops {
+			poss = append(poss, s.Pos)
+		}
+		slices.Sort(poss)
+		poss = slices.Compact(poss)
+		rb.Stops = make([]Stop, 0, len(poss))
+		for _, pos := range poss {
+			abelow, aabove := ab.sideColors(pos)
+			bbelow, babove := bb.sideColors(pos)
+			rb.Stops = append(rb.Stops, Stop{Color: colors.BlendPremult(bt, 100*(1-t), abelow, bbelow), Pos: pos})
+			if abelow != aabove || bbelow != babove {
+				rb.Stops = append(rb.Stops, Stop{Color: colors.BlendPremult(bt, 100*(1-t), aabove, babove), Pos: pos})
+			}
+		}
+	}
+
+	lerpf := func(x, y float32) float32 {
+		return x + (y-x)*t
+	}
+	lerp := func(x, y mat32.Vec2) mat32.Vec2 {
+		return mat32.V2(lerpf(x.X, y.X), lerpf(x.Y, y.Y))
+	}
+	switch r := res.(type) {
+	case *Linear:
+		al, aok := a.(*Linear)
+		bl, bok := b.(*Linear)
+		if !aok || !bok {
+			return res
+		}
+		r.Start = lerp(al.Start, bl.Start)
+		r.End = lerp(al.End, bl.End)
+	case *Radial:
+		ar, aok := a.(*Radial)
+		br, bok := b.(*Radial)
+		if !aok || !bok {
+			return res
+		}
+		r.Center = lerp(ar.Center, br.Center)
+		r.Focal = lerp(ar.Focal, br.Focal)
+		r.Radius = lerp(ar.Radius, br.Radius)
+	case *Conic:
+		ac, aok := a.(*Conic)
+		bc, bok := b.(*Conic)
+		if !aok || !bok {
+			return res
+		}
+		r.Center = lerp(ac.Center, bc.Center)
+		r.Angle = lerpf(ac.Angle, bc.Angle)
+	}
+	at, btf := ab.Transform, bb.Transform
+	rb.Transform = mat32.Mat2{
+		XX: lerpf(at.XX, btf.XX), YX: lerpf(at.YX, btf.YX),
+		XY: lerpf(at.XY, btf.XY), YY: lerpf(at.YY, btf.YY),
+		X0: lerpf(at.X0, btf.X0), Y0: lerpf(at.Y0, btf.Y0),
+	}
+	return res
+}
+
+// sideColors returns the colors of the gradient just below and just above
+// the given position, using the [Pad] spread method. They are the colors of
+// the first and last stops at the position if there are any, which differ
+// for hard stops, and otherwise they are both [Base.PadColor].
+func (b *Base) sideColors(pos float32) (below, above color.Color) {
+	for _, s := range b.Stops {
+		if s.Pos != pos {
+			continue
+		}
+		if below == nil {
+			below = s.Color
+		}
+		above = s.Color
+	}
+	if below == nil {
+		c := b.PadColor(pos)
+		return c, c
+	}
+	return below, above
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gradient
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/colors"
+	"goki.dev/mat32/v2"
+)
+
+func TestInterpolate(t *testing.T) {
+	a := NewLinear().AddStop(colors.White, 0).AddStop(colors.Black, 1)
+	b := NewLinear().SetEnd(mat32.V2(0, 1)).AddStop(colors.Black, 0).AddStop(colors.Red, 0.5).AddStop(colors.White, 1)
+
+	if r := Interpolate(colors.RGB, a, b, 0); !Equal(r, NewLinear().AddStop(colors.White, 0).AddStop(a.GetColor(0.5).(color.RGBA), 0.5).AddStop(colors.Black, 1)) {
+		t.Errorf("expected first gradient at t=0 but got %#v", r)
+	}
+
+	r := Interpolate(colors.RGB, a, b, 0.5).(*Linear)
+	if len(r.Stops) != 3 {
+		t.Fatalf("expected 3 matched stops but got %v", r.Stops)
+	}
+	want := []color.RGBA{colors.Blend(colors.RGB, 50, colors.White, colors.Black), colors.Blend(colors.RGB, 50, a.GetColor(0.5), colors.Red), colors.Blend(colors.RGB, 50, colors.Black, colors.White)}
+	for i, w := range want {
+		if r.Stops[i].Color != w {
+			t.Errorf("stop %d: expected %v but got %v", i, w, r.Stops[i].Color)
+		}
+	}
+	expectVec(t, mat32.V2(0.5, 0.5), r.End)
+
+	// different types use the closest gradient's geometry
+	c := NewRadial().AddStop(colors.Blue, 0).AddStop(colors.Blue, 1)
+	if _, ok := Interpolate(colors.HCT, a, c, 0.75).(*Radial); !ok {
+		t.Errorf("expected radial gradient")
+	}
+}
+
+func TestInterpolateHardStops(t *testing.T) {
+	a := NewLinear().AddStop(colors.Red, 0).AddStop(colors.Red, 0.5).AddStop(colors.Green, 0.5).AddStop(colors.Green, 1)
+	b := NewLinear().AddStop(colors.Blue, 0).AddStop(colors.Blue, 1)
+
+	if r := Interpolate(colors.RGB, a, b, 0); !Equal(r, a) {
+		t.Errorf("expected first gradient with its hard stop at t=0 but got %v", r.AsBase().Stops)
+	}
+	r := Interpolate(colors.RGB, a, b, 0.5).AsBase()
+	want := []Stop{
+		{colors.Blend(colors.RGB, 50, colors.Red, colors.Blue), 0},
+		{colors.Blend(colors.RGB, 50, colors.Red, colors.Blue), 0.5},
+		{colors.Blend(colors.RGB, 50, colors.Green, colors.Blue), 0.5},
+		{colors.Blend(colors.RGB, 50, colors.Green, colors.Blue), 1},
+	}
+	if len(r.Stops) != len(want) {
+		t.Fatalf("expected %v but got %v", want, r.Stops)
+	}
+	for i, w := range want {
+		if r.Stops[i] != w {
+			t.Errorf("stop %d: expected %v but got %v", i, w, r.Stops[i])
+		}
+	}
+	// the hard stop is kept when interpolating the other way
+	if r := Interpolate(colors.RGB, b, a, 1); !Equal(r, a) {
+		t.Errorf("expected second gradient with its hard stop at t=1 but got %v", r.AsBase().Stops)
+	}
+}
@@ -36,7 +36,8 @@ var Scheme = &Schemes.Light
 // SetSchemes sets [Schemes], [Scheme], and [Palette] based on the
 // given primary color. It is the main way that end-user code should
 // set the color schemes to something custom. For more specific control,
-// see [SetSchemesFromKey].
+// see [SetSchemesFromKey], which can be used with [matcolor.KeyFromPrimaryVariant]
+// to use one of the Material scheme variants.
 func SetSchemes(primary color.RGBA) {
 	SetSchemesFromKey(matcolor.KeyFromPrimary(primary))
 }
@@ -62,3 +63,81 @@ func SetScheme(isDark bool) {
 		Scheme = &Schemes.Light
 	}
 }
+
+// InterpolateScheme returns the color scheme that is the given proportion t
+// (from 0 to 1) of the way between the two given color schemes, blending
+// each color using the given blending algorithm. Custom accent colors that
+// are not in both schemes are taken from the scheme that t is closest to.
+// It is useful for animating transitions between color schemes, in which case
+// the progress value can first be passed through an [EasingFunc].
+func InterpolateScheme(bt BlendTypes, a, b *matcolor.Scheme, t float32) matcolor.Scheme {
+	ic := func(ac, bc color.RGBA) color.RGBA {
+		return Blend(bt, 100*(1-t), ac, bc)
+	}
+	ia := func(aa, ba matcolor.Accent) matcolor.Accent {
+		return matcolor.Accent{
+			Base:        ic(aa.Base, ba.Base),
+			On:          ic(aa.On, ba.On),
+			Container:   ic(aa.Container, ba.Container),
+			OnContainer: ic(aa.OnContainer, ba.OnContainer),
+		}
+	}
+	res := matcolor.Scheme{
+		Primary:                 ia(a.Primary, b.Primary),
+		Secondary:               ia(a.Secondary, b.Secondary),
+		Tertiary:                ia(a.Tertiary, b.Tertiary),
+		Select:                  ia(a.Select, b.Select),
+		Error:                   ia(a.Error, b.Error),
+		Success:                 ia(a.Success, b.Success),
+		Warn:                    ia(a.Warn, b.Warn),
+		SurfaceDim:              ic(a.SurfaceDim, b.SurfaceDim),
+		Surface:                 ic(a.Surface, b.Surface),
+		SurfaceBright:           ic(a.SurfaceBright, b.SurfaceBright),
+		SurfaceContainerLowest:  ic(a.SurfaceContainerLowest, b.SurfaceContainerLowest),
+		SurfaceContainerLow:     ic(a.SurfaceContainerLow, b.SurfaceContainerLow),
+		SurfaceContainer:        ic(a.SurfaceContainer, b.SurfaceContainer),
+		SurfaceContainerHigh:    ic(a.SurfaceContainerHigh, b.SurfaceContainerHigh),
+		SurfaceContainerHighest: ic(a.SurfaceContainerHighest, b.SurfaceContainerHighest),
+		SurfaceVariant:          ic(a.SurfaceVariant, b.SurfaceVariant),
+		OnSurface:               ic(a.OnSurface, b.OnSurface),
+		OnSurfaceVariant:        ic(a.OnSurfaceVariant, b.OnSurfaceVariant),
+		InverseSurface:          ic(a.InverseSurface, b.InverseSurface),
+		InverseOnSurface:        ic(a.InverseOnSurface, b.InverseOnSurface),
+		InversePrimary:          ic(a.InversePrimary, b.InversePrimary),
+		Background:              ic(a.Background, b.Background),
+		OnBackground:            ic(a.OnBackground, b.OnBackground),
+		Outline:                 ic(a.Outline, b.Outline),
+		OutlineVariant:          ic(a.OutlineVariant, b.OutlineVariant),
+		Shadow:                  ic(a.Shadow, b.Shadow),
+		SurfaceTint:             ic(a.SurfaceTint, b.SurfaceTint),
+		Scrim:                   ic(a.Scrim, b.Scrim),
+		PrimaryFixed:            ic(a.PrimaryFixed, b.PrimaryFixed),
+		PrimaryFixedDim:         ic(a.PrimaryFixedDim, b.PrimaryFixedDim),
+		OnPrimaryFixed:          ic(a.OnPrimaryFixed, b.OnPrimaryFixed),
+		OnPrimaryFixedVariant:   ic(a.OnPrimaryFixedVariant, b.OnPrimaryFixedVariant),
+		SecondaryFixed:          ic(a.SecondaryFixed, b.SecondaryFixed),
+		SecondaryFixedDim:       ic(a.SecondaryFixedDim, b.SecondaryFixedDim),
+		OnSecondaryFixed:        ic(a.OnSecondaryFixed, b.OnSecondaryFixed),
+		OnSecondaryFixedVariant: ic(a.OnSecondaryFixedVariant, b.OnSecondaryFixedVariant),
+		TertiaryFixed:           ic(a.TertiaryFixed, b.TertiaryFixed),
+		TertiaryFixedDim:        ic(a.TertiaryFixedDim, b.TertiaryFixedDim),
+		OnTertiaryFixed:         ic(a.OnTertiaryFixed, b.OnTertiaryFixed),
+		OnTertiaryFixedVariant:  ic(a.OnTertiaryFixedVariant, b.OnTertiaryFixedVariant),
+	}
+	near := a.Custom
+	if t >= 0.5 {
+		near = b.Custom
+	}
+	if near != nil {
+		res.Custom = make(map[string]matcolor.Accent, len(near))
+		for k, v := range near {
+			av, aok := a.Custom[k]
+			bv, bok := b.Custom[k]
+			if aok && bok {
+				v = ia(av, bv)
+			}
+			res.Custom[k] = v
+		}
+	}
+	return res
+}
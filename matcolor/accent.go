@@ -4,7 +4,11 @@
 
 package matcolor
 
-import "image/color"
+import (
+	"image/color"
+
+	"goki.dev/cam/hct"
+)
 
 // Accent contains the four standard variations of a base accent color.
 type Accent struct { //gti:add
@@ -24,20 +28,48 @@ type Accent struct { //gti:add
 
 // NewAccentLight returns a new light theme [Accent] from the given [Tones]
 func NewAccentLight(tones Tones) Accent {
-	return Accent{
-		Base:        tones.AbsTone(40),
-		On:          tones.AbsTone(100),
-		Container:   tones.AbsTone(90),
-		OnContainer: tones.AbsTone(10),
-	}
+	return NewAccentLightContrast(tones, ContrastStandard)
 }
 
 // NewAccentDark returns a new dark theme [Accent] from the given [Tones]
 func NewAccentDark(tones Tones) Accent {
+	return NewAccentDarkContrast(tones, ContrastStandard)
+}
+
+// NewAccentLightContrast returns a new light theme [Accent] from the given
+// [Tones] with the given contrast level (see [NewLightSchemeContrast]).
+func NewAccentLightContrast(tones Tones, level float32) Accent {
+	return newAccent(tones, 98, 40, 100, 90, 10, level)
+}
+
+// NewAccentDarkContrast returns a new dark theme [Accent] from the given
+// [Tones] with the given contrast level (see [NewDarkSchemeContrast]).
+func NewAccentDarkContrast(tones Tones, level float32) Accent {
+	return newAccent(tones, 6, 80, 20, 30, 90, level)
+}
+
+// newAccent returns a new [Accent] from the given [Tones] with the given
+// standard tones for each color on a surface with the given tone, with
+// the tones of the base and content colors adjusted for the given contrast
+// level (see [contrastTone]). For positive levels, the container tone is also
+// adjusted to contrast with the surface (see [contrastContainer]), keeping the
+// base tone farther from the surface (see [separateTones]), and the content on
+// the container always has at least its standard contrast ratio with it.
+func newAccent(tones Tones, surface, base, on, container, onContainer int, level float32) Accent {
+	base = contrastTone(base, surface, accentCurve, level)
+	if level > 0 {
+		ratio := max(hct.ToneContrastRatio(float32(onContainer), float32(container)), onCurve.ratio(level))
+		var lighter bool
+		container, lighter = contrastContainer(surface, container, onContainer, level)
+		container, base = separateTones(container, base, surface < 50)
+		onContainer = contentTone(container, ratio, lighter)
+	} else {
+		onContainer = contrastTone(onContainer, container, onCurve, level)
+	}
 	return Accent{
-		Base:        tones.AbsTone(80),
-		On:          tones.AbsTone(20),
-		Container:   tones.AbsTone(30),
-		OnContainer: tones.AbsTone(90),
+		Base:        tones.AbsTone(base),
+		On:          tones.AbsTone(contrastTone(on, base, onCurve, level)),
+		Container:   tones.AbsTone(container),
+		OnContainer: tones.AbsTone(onContainer),
 	}
 }
@@ -0,0 +1,167 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/dynamiccolor/src/contrast_curve.dart
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcolor
+
+import (
+	"goki.dev/cam/hct"
+	"goki.dev/mat32/v2"
+)
+
+const (
+	// ContrastReduced is the contrast level for reduced contrast
+	// schemes (see [NewLightSchemeContrast]).
+	ContrastReduced float32 = -1
+
+	// ContrastStandard is the contrast level for standard contrast
+	// schemes (see [NewLightSchemeContrast]), which is the default.
+	ContrastStandard float32 = 0
+
+	// ContrastMedium is the contrast level for medium contrast
+	// schemes (see [NewLightSchemeContrast]).
+	ContrastMedium float32 = 0.5
+
+	// ContrastHigh is the contrast level for high contrast
+	// schemes (see [NewLightSchemeContrast]).
+	ContrastHigh float32 = 1
+)
+
+// contrastCurve contains the target contrast ratios of a color role
+// with its background at the reduced, standard, medium, and high
+// contrast levels.
+type contrastCurve struct {
+	low, normal, medium, high float32
+}
+
+// the contrast curves for the different kinds of color roles, as in Material
+var (
+	// accent colors (Base) and inverse primary
+	accentCurve = contrastCurve{3, 4.5, 7, 7}
+
+	// content on top of other colors (On, OnContainer, OnSurface, etc)
+	onCurve = contrastCurve{4.5, 7, 11, 21}
+
+	// outline variants
+	outlineVariantCurve = contrastCurve{1, 1, 3, 4.5}
+
+	// accent containers (Container)
+	containerCurve = contrastCurve{1, 1, 3, 4.5}
+
+	// content variants (OnSurfaceVariant)
+	variantCurve = contrastCurve{3, 4.5, 7, 11}
+
+	// outlines
+	outlineCurve = contrastCurve{1.5, 3, 4.5, 7}
+)
+
+// ratio returns the target contrast ratio for the given contrast level
+// (-1 to 1), linearly interpolating between the levels of the curve.
+func (c contrastCurve) ratio(level float32) float32 {
+	switch {
+	case level <= -1:
+		return c.low
+	case level < 0:
+		return c.low + (c.normal-c.low)*(level+1)
+	case level < 0.5:
+		return c.normal + (c.medium-c.normal)*level/0.5
+	case level < 1:
+		return c.medium + (c.high-c.medium)*(level-0.5)/0.5
+	}
+	return c.high
+}
+
+// contrastTone returns the tone of a color role that has the given standard
+// tone on a background with the given tone, adjusted for the given contrast
+// level (-1 to 1) to meet the target contrast ratio of the given curve.
+// The tone stays on the same side of the background as the standard tone,
+// and it is the standard tone for the standard contrast level. For positive
+// levels, the contrast is at least that of the standard tone, and for negative
+// levels, it is at most that of the standard tone. If the target contrast
+// ratio can not be met, the tone with the highest contrast is returned.
+func contrastTone(std, bg int, curve contrastCurve, level float32) int {
+	if level == 0 {
+		return std
+	}
+	ratio := curve.ratio(mat32.Clamp(level, -1, 1))
+	var tone int
+	if std >= bg {
+		tone = int(mat32.Ceil(hct.ContrastToneLighter(float32(bg), ratio)))
+		if level > 0 {
+			return min(max(tone, std), 100)
+		}
+		return max(min(tone, std), bg)
+	}
+	tone = int(mat32.Floor(hct.ContrastToneDarker(float32(bg), ratio)))
+	if level > 0 {
+		return max(min(tone, std), 0)
+	}
+	return min(max(tone, std), bg)
+}
+
+// foregroundTone returns the tone of content on top of a color with the given
+// tone that has the given contrast ratio with it, as in Material. Content is
+// lighter for tones below 60 and darker otherwise, unless only the other side
+// can meet the ratio or has a higher contrast.
+func foregroundTone(bg int, ratio float32) int {
+	b := float32(bg)
+	lighter, darker := hct.ContrastToneLighter(b, ratio), hct.ContrastToneDarker(b, ratio)
+	lr, dr := hct.ToneContrastRatio(lighter, b), hct.ToneContrastRatio(darker, b)
+	useLighter := lr >= ratio || lr >= dr
+	if bg >= 60 {
+		useLighter = dr < ratio && dr < lr
+	}
+	if useLighter {
+		return int(mat32.Ceil(lighter))
+	}
+	return int(mat32.Floor(darker))
+}
+
+// contrastContainer returns the tone of an accent container with the given
+// standard tone on a surface with the given tone, adjusted for the given
+// positive contrast level to meet the target contrast ratio of [containerCurve],
+// and whether the content on it is lighter than it. If the content, which has
+// the given standard tone, could no longer have its standard contrast ratio
+// with the adjusted container, the container moves past the middle tones and
+// the content switches between dark and light, as in the high contrast schemes
+// of Material. The container then has the tone closest to the middle at which
+// the content can meet its target contrast ratio (see [onCurve]), within tones
+// 10 to 90.
+func contrastContainer(surface, container, onContainer int, level float32) (int, bool) {
+	std := hct.ToneContrastRatio(float32(onContainer), float32(container))
+	lighter := onContainer > container
+	tone := contrastTone(container, surface, containerCurve, level)
+	if hct.ToneContrastRatio(float32(tone), float32(contentTone(tone, std, lighter))) >= std {
+		return tone, lighter
+	}
+	ratio := max(std, onCurve.ratio(level))
+	if lighter {
+		return min(int(mat32.Ceil(hct.ContrastToneLighter(0, ratio))), 90), false
+	}
+	return max(int(mat32.Floor(hct.ContrastToneDarker(100, ratio))), 10), true
+}
+
+// contentTone returns the tone of content on top of a color with the given
+// tone that has the given contrast ratio with it, on the given side of it.
+// If the ratio can not be met, the tone with the highest contrast is returned.
+func contentTone(bg int, ratio float32, lighter bool) int {
+	if lighter {
+		return min(int(mat32.Ceil(hct.ContrastToneLighter(float32(bg), ratio))), 100)
+	}
+	return max(int(mat32.Floor(hct.ContrastToneDarker(float32(bg), ratio))), 0)
+}
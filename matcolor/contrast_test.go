@@ -0,0 +1,85 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcolor
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"goki.dev/cam/hct"
+)
+
+func TestSchemeContrast(t *testing.T) {
+	p := NewPalette(KeyFromPrimary(color.RGBA{66, 133, 244, 255}))
+	if !reflect.DeepEqual(NewLightScheme(p), NewLightSchemeContrast(p, ContrastStandard)) {
+		t.Errorf("expected standard contrast light scheme to be the same as NewLightScheme")
+	}
+	if !reflect.DeepEqual(NewDarkScheme(p), NewDarkSchemeContrast(p, ContrastStandard)) {
+		t.Errorf("expected standard contrast dark scheme to be the same as NewDarkScheme")
+	}
+
+	// the pairs of colors to check, with their minimum contrast
+	// ratios for medium and high contrast
+	type pair struct {
+		name         string
+		fg, bg       func(s *Scheme) color.RGBA
+		medium, high float32
+	}
+	pairs := []pair{
+		{"OnPrimary/Primary", func(s *Scheme) color.RGBA { return s.Primary.On }, func(s *Scheme) color.RGBA { return s.Primary.Base }, 11, 21},
+		{"OnPrimaryContainer/PrimaryContainer", func(s *Scheme) color.RGBA { return s.Primary.OnContainer }, func(s *Scheme) color.RGBA { return s.Primary.Container }, 11, 21},
+		{"Primary/Surface", func(s *Scheme) color.RGBA { return s.Primary.Base }, func(s *Scheme) color.RGBA { return s.Surface }, 7, 7},
+		{"PrimaryContainer/Surface", func(s *Scheme) color.RGBA { return s.Primary.Container }, func(s *Scheme) color.RGBA { return s.Surface }, 3, 4.5},
+		{"TertiaryContainer/Surface", func(s *Scheme) color.RGBA { return s.Tertiary.Container }, func(s *Scheme) color.RGBA { return s.Surface }, 3, 4.5},
+		{"OnTertiaryContainer/TertiaryContainer", func(s *Scheme) color.RGBA { return s.Tertiary.OnContainer }, func(s *Scheme) color.RGBA { return s.Tertiary.Container }, 11, 21},
+		{"OnError/Error", func(s *Scheme) color.RGBA { return s.Error.On }, func(s *Scheme) color.RGBA { return s.Error.Base }, 11, 21},
+		{"OnSurface/Surface", func(s *Scheme) color.RGBA { return s.OnSurface }, func(s *Scheme) color.RGBA { return s.Surface }, 11, 21},
+		{"OnSurfaceVariant/Surface", func(s *Scheme) color.RGBA { return s.OnSurfaceVariant }, func(s *Scheme) color.RGBA { return s.Surface }, 7, 11},
+		{"Outline/Surface", func(s *Scheme) color.RGBA { return s.Outline }, func(s *Scheme) color.RGBA { return s.Surface }, 4.5, 7},
+		{"InverseOnSurface/InverseSurface", func(s *Scheme) color.RGBA { return s.InverseOnSurface }, func(s *Scheme) color.RGBA { return s.InverseSurface }, 11, 21},
+	}
+	for _, dark := range []bool{false, true} {
+		newScheme := NewLightSchemeContrast
+		if dark {
+			newScheme = NewDarkSchemeContrast
+		}
+		std, med, high, red := newScheme(p, ContrastStandard), newScheme(p, ContrastMedium), newScheme(p, ContrastHigh), newScheme(p, ContrastReduced)
+		for _, pr := range pairs {
+			stdr := hct.ContrastRatio(pr.fg(&std), pr.bg(&std))
+			medr := hct.ContrastRatio(pr.fg(&med), pr.bg(&med))
+			highr := hct.ContrastRatio(pr.fg(&high), pr.bg(&high))
+			redr := hct.ContrastRatio(pr.fg(&red), pr.bg(&red))
+			// the target contrast ratio must be met unless the
+			// foreground color already has the highest contrast
+			if (medr < pr.medium-0.1 && !isBlackOrWhite(pr.fg(&med))) || (highr < pr.high-0.1 && !isBlackOrWhite(pr.fg(&high))) {
+				t.Errorf("%s (dark: %v): expected contrast ratios of at least %g and %g but got %g and %g", pr.name, dark, pr.medium, pr.high, medr, highr)
+			}
+			if redr > stdr+0.01 || stdr > medr+0.01 || medr > highr+0.01 {
+				t.Errorf("%s (dark: %v): expected contrast ratios to increase with contrast level but got %g, %g, %g, and %g", pr.name, dark, redr, stdr, medr, highr)
+			}
+		}
+	}
+}
+
+// isBlackOrWhite returns whether the given color is black or white.
+func isBlackOrWhite(c color.RGBA) bool {
+	return c == color.RGBA{0, 0, 0, 255} || c == color.RGBA{255, 255, 255, 255}
+}
+
+func TestFixedColors(t *testing.T) {
+	p := NewPalette(KeyFromPrimary(color.RGBA{66, 133, 244, 255}))
+	l, d := NewLightScheme(p), NewDarkScheme(p)
+	if l.PrimaryFixed != d.PrimaryFixed || l.OnTertiaryFixedVariant != d.OnTertiaryFixedVariant {
+		t.Errorf("expected fixed colors to be the same in light and dark schemes")
+	}
+	if l.SecondaryFixed != p.Secondary.AbsTone(90) || l.OnPrimaryFixed != p.Primary.AbsTone(10) {
+		t.Errorf("expected fixed colors to have the standard Material tones")
+	}
+	h := NewLightSchemeContrast(p, ContrastHigh)
+	if r := hct.ContrastRatio(h.OnPrimaryFixed, h.PrimaryFixedDim); r < 11 {
+		t.Errorf("expected a high contrast ratio between OnPrimaryFixed and PrimaryFixedDim but got %g", r)
+	}
+}
@@ -0,0 +1,156 @@
+// Code generated by "goki generate ./..."; DO NOT EDIT.
+
+package matcolor
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"goki.dev/enums"
+)
+
+var _VariantsValues = []Variants{0, 1, 2, 3, 4, 5, 6, 7, 8}
+
+// VariantsN is the highest valid value
+// for type Variants, plus one.
+const VariantsN Variants = 9
+
+// An "invalid array index" compiler error signifies that the constant values have changed.
+// Re-run the enumgen command to generate them again.
+func _VariantsNoOp() {
+	var x [1]struct{}
+	_ = x[TonalSpot-(0)]
+	_ = x[Vibrant-(1)]
+	_ = x[Expressive-(2)]
+	_ = x[Fidelity-(3)]
+	_ = x[Content-(4)]
+	_ = x[Monochrome-(5)]
+	_ = x[Neutral-(6)]
+	_ = x[Rainbow-(7)]
+	_ = x[FruitSalad-(8)]
+}
+
+var _VariantsNameToValueMap = map[string]Variants{
+	`TonalSpot`:  0,
+	`tonalspot`:  0,
+	`Vibrant`:    1,
+	`vibrant`:    1,
+	`Expressive`: 2,
+	`expressive`: 2,
+	`Fidelity`:   3,
+	`fidelity`:   3,
+	`Content`:    4,
+	`content`:    4,
+	`Monochrome`: 5,
+	`monochrome`: 5,
+	`Neutral`:    6,
+	`neutral`:    6,
+	`Rainbow`:    7,
+	`rainbow`:    7,
+	`FruitSalad`: 8,
+	`fruitsalad`: 8,
+}
+
+var _VariantsDescMap = map[Variants]string{
+	0: `TonalSpot is the default Material variant, which results in a calm scheme with a moderately colorful primary color and muted secondary and tertiary colors.`,
+	1: `Vibrant results in a colorful scheme with the highest available chroma for the primary color and secondary and tertiary colors with hues that are rotated from the source color.`,
+	2: `Expressive results in a playful scheme in which the primary color does not have the hue of the source color.`,
+	3: `Fidelity results in a scheme with a primary color that matches the source color, even if it is highly chromatic, and a tertiary color that is the complement of the source color in temperature.`,
+	4: `Content is like [Fidelity], but with a tertiary color that is analogous to the source color in temperature, which is designed for schemes based on the colors of content such as images.`,
+	5: `Monochrome results in a grayscale scheme.`,
+	6: `Neutral results in a nearly grayscale scheme with a hint of the hue of the source color.`,
+	7: `Rainbow results in a playful scheme with a colorful primary color and grayscale neutral colors.`,
+	8: `FruitSalad results in a playful scheme in which the primary and secondary colors have hues that are rotated from the source color.`,
+}
+
+var _VariantsMap = map[Variants]string{
+	0: `TonalSpot`,
+	1: `Vibrant`,
+	2: `Expressive`,
+	3: `Fidelity`,
+	4: `Content`,
+	5: `Monochrome`,
+	6: `Neutral`,
+	7: `Rainbow`,
+	8: `FruitSalad`,
+}
+
+// String returns the string representation
+// of this Variants value.
+func (i Variants) String() string {
+	if str, ok := _VariantsMap[i]; ok {
+		return str
+	}
+	return strconv.FormatInt(int64(i), 10)
+}
+
+// SetString sets the Variants value from its
+// string representation, and returns an
+// error if the string is invalid.
+func (i *Variants) SetString(s string) error {
+	if val, ok := _VariantsNameToValueMap[s]; ok {
+		*i = val
+		return nil
+	}
+	if val, ok := _VariantsNameToValueMap[strings.ToLower(s)]; ok {
+		*i = val
+		return nil
+	}
+	return errors.New(s + " is not a valid value for type Variants")
+}
+
+// Int64 returns the Variants value as an int64.
+func (i Variants) Int64() int64 {
+	return int64(i)
+}
+
+// SetInt64 sets the Variants value from an int64.
+func (i *Variants) SetInt64(in int64) {
+	*i = Variants(in)
+}
+
+// Desc returns the description of the Variants value.
+func (i Variants) Desc() string {
+	if str, ok := _VariantsDescMap[i]; ok {
+		return str
+	}
+	return i.String()
+}
+
+// VariantsValues returns all possible values
+// for the type Variants.
+func VariantsValues() []Variants {
+	return _VariantsValues
+}
+
+// Values returns all possible values
+// for the type Variants.
+func (i Variants) Values() []enums.Enum {
+	res := make([]enums.Enum, len(_VariantsValues))
+	for i, d := range _VariantsValues {
+		res[i] = d
+	}
+	return res
+}
+
+// IsValid returns whether the value is a
+// valid option for type Variants.
+func (i Variants) IsValid() bool {
+	_, ok := _VariantsMap[i]
+	return ok
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (i Variants) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (i *Variants) UnmarshalText(text []byte) error {
+	if err := i.SetString(string(text)); err != nil {
+		log.Println(err)
+	}
+	return nil
+}
@@ -44,6 +44,8 @@ var _ = gti.AddType(&gti.Type{
 		{"Neutral", &gti.Field{Name: "Neutral", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "the neutral key color used to generate surface and surface container colors", Directives: gti.Directives{}, Tag: ""}},
 		{"NeutralVariant", &gti.Field{Name: "NeutralVariant", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "the neutral variant key color used to generate surface variant and outline colors", Directives: gti.Directives{}, Tag: ""}},
 		{"Custom", &gti.Field{Name: "Custom", Type: "map[string]image/color.RGBA", LocalType: "map[string]color.RGBA", Doc: "an optional map of custom accent key colors", Directives: gti.Directives{}, Tag: ""}},
+		{"Variant", &gti.Field{Name: "Variant", Type: "goki.dev/colors/matcolor.Variants", LocalType: "Variants", Doc: "the variant that the key colors were derived with (see\n[KeyFromPrimaryVariant]), which determines the tones of some of the\naccent colors in the schemes based on the key", Directives: gti.Directives{}, Tag: ""}},
+		{"Source", &gti.Field{Name: "Source", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "the source color that the key colors were derived from with\n[KeyFromPrimaryVariant], which determines the tones of the accent\ncontainers in the [Fidelity] and [Content] variants", Directives: gti.Directives{}, Tag: ""}},
 	}),
 	Embeds:  ordmap.Make([]ordmap.KeyVal[string, *gti.Field]{}),
 	Methods: ordmap.Make([]ordmap.KeyVal[string, *gti.Method]{}),
@@ -68,6 +70,8 @@ var _ = gti.AddType(&gti.Type{
 		{"Neutral", &gti.Field{Name: "Neutral", Type: "goki.dev/colors/matcolor.Tones", LocalType: "Tones", Doc: "the tones for the neutral key color", Directives: gti.Directives{}, Tag: ""}},
 		{"NeutralVariant", &gti.Field{Name: "NeutralVariant", Type: "goki.dev/colors/matcolor.Tones", LocalType: "Tones", Doc: "the tones for the neutral variant key color", Directives: gti.Directives{}, Tag: ""}},
 		{"Custom", &gti.Field{Name: "Custom", Type: "map[string]goki.dev/colors/matcolor.Tones", LocalType: "map[string]Tones", Doc: "an optional map of tones for custom accent key colors", Directives: gti.Directives{}, Tag: ""}},
+		{"Variant", &gti.Field{Name: "Variant", Type: "goki.dev/colors/matcolor.Variants", LocalType: "Variants", Doc: "the variant of the key colors (see [Key.Variant])", Directives: gti.Directives{}, Tag: ""}},
+		{"Source", &gti.Field{Name: "Source", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "the source color of the key colors (see [Key.Source])", Directives: gti.Directives{}, Tag: ""}},
 	}),
 	Embeds:  ordmap.Make([]ordmap.KeyVal[string, *gti.Field]{}),
 	Methods: ordmap.Make([]ordmap.KeyVal[string, *gti.Method]{}),
@@ -111,6 +115,18 @@ var _ = gti.AddType(&gti.Type{
 		{"Shadow", &gti.Field{Name: "Shadow", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "Shadow is the color applied to shadows", Directives: gti.Directives{}, Tag: ""}},
 		{"SurfaceTint", &gti.Field{Name: "SurfaceTint", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "SurfaceTint is the color applied to tint surfaces", Directives: gti.Directives{}, Tag: ""}},
 		{"Scrim", &gti.Field{Name: "Scrim", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "Scrim is the color applied to scrims (semi-transparent overlays)", Directives: gti.Directives{}, Tag: ""}},
+		{"PrimaryFixed", &gti.Field{Name: "PrimaryFixed", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "PrimaryFixed is a primary fill color that stays the same regardless of color scheme type (light/dark)", Directives: gti.Directives{}, Tag: ""}},
+		{"PrimaryFixedDim", &gti.Field{Name: "PrimaryFixedDim", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "PrimaryFixedDim is a higher-emphasis, dimmer primary fill color that stays the same regardless of color scheme type (light/dark)", Directives: gti.Directives{}, Tag: ""}},
+		{"OnPrimaryFixed", &gti.Field{Name: "OnPrimaryFixed", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "OnPrimaryFixed is the color applied to high-emphasis content on top of PrimaryFixed", Directives: gti.Directives{}, Tag: ""}},
+		{"OnPrimaryFixedVariant", &gti.Field{Name: "OnPrimaryFixedVariant", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "OnPrimaryFixedVariant is the color applied to low-emphasis content on top of PrimaryFixed", Directives: gti.Directives{}, Tag: ""}},
+		{"SecondaryFixed", &gti.Field{Name: "SecondaryFixed", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "SecondaryFixed is a secondary fill color that stays the same regardless of color scheme type (light/dark)", Directives: gti.Directives{}, Tag: ""}},
+		{"SecondaryFixedDim", &gti.Field{Name: "SecondaryFixedDim", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "SecondaryFixedDim is a higher-emphasis, dimmer secondary fill color that stays the same regardless of color scheme type (light/dark)", Directives: gti.Directives{}, Tag: ""}},
+		{"OnSecondaryFixed", &gti.Field{Name: "OnSecondaryFixed", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "OnSecondaryFixed is the color applied to high-emphasis content on top of SecondaryFixed", Directives: gti.Directives{}, Tag: ""}},
+		{"OnSecondaryFixedVariant", &gti.Field{Name: "OnSecondaryFixedVariant", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "OnSecondaryFixedVariant is the color applied to low-emphasis content on top of SecondaryFixed", Directives: gti.Directives{}, Tag: ""}},
+		{"TertiaryFixed", &gti.Field{Name: "TertiaryFixed", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "TertiaryFixed is a tertiary fill color that stays the same regardless of color scheme type (light/dark)", Directives: gti.Directives{}, Tag: ""}},
+		{"TertiaryFixedDim", &gti.Field{Name: "TertiaryFixedDim", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "TertiaryFixedDim is a higher-emphasis, dimmer tertiary fill color that stays the same regardless of color scheme type (light/dark)", Directives: gti.Directives{}, Tag: ""}},
+		{"OnTertiaryFixed", &gti.Field{Name: "OnTertiaryFixed", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "OnTertiaryFixed is the color applied to high-emphasis content on top of TertiaryFixed", Directives: gti.Directives{}, Tag: ""}},
+		{"OnTertiaryFixedVariant", &gti.Field{Name: "OnTertiaryFixedVariant", Type: "image/color.RGBA", LocalType: "color.RGBA", Doc: "OnTertiaryFixedVariant is the color applied to low-emphasis content on top of TertiaryFixed", Directives: gti.Directives{}, Tag: ""}},
 	}),
 	Embeds:  ordmap.Make([]ordmap.KeyVal[string, *gti.Field]{}),
 	Methods: ordmap.Make([]ordmap.KeyVal[string, *gti.Method]{}),
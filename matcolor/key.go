@@ -58,9 +58,21 @@ type Key struct { //gti:add
 
 	// an optional map of custom accent key colors
 	Custom map[string]color.RGBA
+
+	// the variant that the key colors were derived with (see
+	// [KeyFromPrimaryVariant]), which determines the tones of some of the
+	// accent colors in the schemes based on the key
+	Variant Variants
+
+	// the source color that the key colors were derived from with
+	// [KeyFromPrimaryVariant], which determines the tones of the accent
+	// containers in the [Fidelity] and [Content] variants
+	Source color.RGBA
 }
 
 // Key returns a new [Key] from the given primary accent key color.
+// The key colors are derived similarly to the Material [TonalSpot] variant;
+// see [KeyFromPrimaryVariant] for the exact Material variants.
 func KeyFromPrimary(primary color.RGBA) *Key {
 	k := &Key{}
 	p := hct.FromColor(primary)
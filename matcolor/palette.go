@@ -4,6 +4,8 @@
 
 package matcolor
 
+import "image/color"
+
 // Palette contains a tonal palette with tonal values
 // for each of the standard colors and any custom colors.
 // Use [NewPalette] to create a new palette.
@@ -38,6 +40,12 @@ type Palette struct { //gti:add
 
 	// an optional map of tones for custom accent key colors
 	Custom map[string]Tones
+
+	// the variant of the key colors (see [Key.Variant])
+	Variant Variants
+
+	// the source color of the key colors (see [Key.Source])
+	Source color.RGBA
 }
 
 // NewPalette creates a new [Palette] from the given key colors.
@@ -52,6 +60,8 @@ func NewPalette(key *Key) *Palette {
 		Warn:           NewTones(key.Warn),
 		Neutral:        NewTones(key.Neutral),
 		NeutralVariant: NewTones(key.NeutralVariant),
+		Variant:        key.Variant,
+		Source:         key.Source,
 	}
 	for name, c := range key.Custom {
 		p.Custom[name] = NewTones(c)
@@ -99,54 +99,82 @@ type Scheme struct { //gti:add
 	// Scrim is the color applied to scrims (semi-transparent overlays)
 	Scrim color.RGBA
 
-	// // PrimaryFixed is a primary fill color that stays the same regardless of color scheme type (light/dark)
-	// PrimaryFixed color.RGBA `desc:"PrimaryFixed is a primary fill color that stays the same regardless of color scheme type (light/dark)"`
+	// PrimaryFixed is a primary fill color that stays the same regardless of color scheme type (light/dark)
+	PrimaryFixed color.RGBA
 
-	// // PrimaryFixedDim is a higher-emphasis, dimmer primary fill color that stays the same regardless of color scheme type (light/dark)
-	// PrimaryFixedDim color.RGBA `desc:"PrimaryFixedDim is a higher-emphasis, dimmer primary fill color that stays the same regardless of color scheme type (light/dark)"`
+	// PrimaryFixedDim is a higher-emphasis, dimmer primary fill color that stays the same regardless of color scheme type (light/dark)
+	PrimaryFixedDim color.RGBA
 
-	// // OnPrimaryFixed is the color applied to high-emphasis content on top of PrimaryFixed
-	// OnPrimaryFixed color.RGBA `desc:"OnPrimaryFixed is the color applied to high-emphasis content on top of PrimaryFixed"`
+	// OnPrimaryFixed is the color applied to high-emphasis content on top of PrimaryFixed
+	OnPrimaryFixed color.RGBA
 
-	// // OnPrimaryFixedVariant is the color applied to low-emphasis content on top of PrimaryFixed
-	// OnPrimaryFixedVariant color.RGBA `desc:"OnPrimaryFixedVariant is the color applied to low-emphasis content on top of PrimaryFixed"`
+	// OnPrimaryFixedVariant is the color applied to low-emphasis content on top of PrimaryFixed
+	OnPrimaryFixedVariant color.RGBA
 
-	// // SecondaryFixed is a secondary fill color that stays the same regardless of color scheme type (light/dark)
-	// SecondaryFixed color.RGBA `desc:"SecondaryFixed is a secondary fill color that stays the same regardless of color scheme type (light/dark)"`
+	// SecondaryFixed is a secondary fill color that stays the same regardless of color scheme type (light/dark)
+	SecondaryFixed color.RGBA
 
-	// // SecondaryFixedDim is a higher-emphasis, dimmer secondary fill color that stays the same regardless of color scheme type (light/dark)
-	// SecondaryFixedDim color.RGBA `desc:"SecondaryFixedDim is a higher-emphasis, dimmer secondary fill color that stays the same regardless of color scheme type (light/dark)"`
+	// SecondaryFixedDim is a higher-emphasis, dimmer secondary fill color that stays the same regardless of color scheme type (light/dark)
+	SecondaryFixedDim color.RGBA
 
-	// // OnSecondaryFixed is the color applied to high-emphasis content on top of SecondaryFixed
-	// OnSecondaryFixed color.RGBA `desc:"OnSecondaryFixed is the color applied to high-emphasis content on top of SecondaryFixed"`
+	// OnSecondaryFixed is the color applied to high-emphasis content on top of SecondaryFixed
+	OnSecondaryFixed color.RGBA
 
-	// // OnSecondaryFixedVariant is the color applied to low-emphasis content on top of SecondaryFixed
-	// OnSecondaryFixedVariant color.RGBA `desc:"OnSecondaryFixedVariant is the color applied to low-emphasis content on top of SecondaryFixed"`
+	// OnSecondaryFixedVariant is the color applied to low-emphasis content on top of SecondaryFixed
+	OnSecondaryFixedVariant color.RGBA
 
-	// // TertiaryFixed is a tertiary fill color that stays the same regardless of color scheme type (light/dark)
-	// TertiaryFixed color.RGBA `desc:"TertiaryFixed is a tertiary fill color that stays the same regardless of color scheme type (light/dark)"`
+	// TertiaryFixed is a tertiary fill color that stays the same regardless of color scheme type (light/dark)
+	TertiaryFixed color.RGBA
 
-	// // TertiaryFixedDim is a higher-emphasis, dimmer tertiary fill color that stays the same regardless of color scheme type (light/dark)
-	// TertiaryFixedDim color.RGBA `desc:"TertiaryFixedDim is a higher-emphasis, dimmer tertiary fill color that stays the same regardless of color scheme type (light/dark)"`
+	// TertiaryFixedDim is a higher-emphasis, dimmer tertiary fill color that stays the same regardless of color scheme type (light/dark)
+	TertiaryFixedDim color.RGBA
 
-	// // OnTertiaryFixed is the color applied to high-emphasis content on top of TertiaryFixed
-	// OnTertiaryFixed color.RGBA `desc:"OnTertiaryFixed is the color applied to high-emphasis content on top of TertiaryFixed"`
+	// OnTertiaryFixed is the color applied to high-emphasis content on top of TertiaryFixed
+	OnTertiaryFixed color.RGBA
 
-	// // OnTertiaryFixedVariant is the color applied to low-emphasis content on top of TertiaryFixed
-	// OnTertiaryFixedVariant color.RGBA `desc:"OnTertiaryFixedVariant is the color applied to low-emphasis content on top of TertiaryFixed"`
+	// OnTertiaryFixedVariant is the color applied to low-emphasis content on top of TertiaryFixed
+	OnTertiaryFixedVariant color.RGBA
 }
 
 // NewLightScheme returns a new light-themed [Scheme]
 // based on the given [Palette].
 func NewLightScheme(p *Palette) Scheme {
+	return NewLightSchemeContrast(p, ContrastStandard)
+}
+
+// NewLightSchemeContrast returns a new light-themed [Scheme] based on the
+// given [Palette] with the given contrast level, which ranges from -1
+// ([ContrastReduced]) to 1 ([ContrastHigh]), with 0 ([ContrastStandard])
+// resulting in the same scheme as [NewLightScheme]. As in Material, the tones
+// of the colors that are placed on top of other colors (such as the accent
+// colors, On colors, and outlines) are adjusted to meet target contrast ratios
+// with the colors they are placed on, which increase with the contrast level,
+// or to have the highest possible contrast if the target can not be met. For
+// example, OnSurface has a target contrast ratio with Surface of 11 for medium
+// contrast and 21 (black or white) for high contrast, and Outline has a target
+// contrast ratio with Surface of 4.5 for medium contrast and 7 for high contrast.
+// For positive contrast levels, the tones of the accent containers (such as
+// the Container of Primary) are also adjusted to have a target contrast ratio
+// with Surface of 3 for medium contrast and 4.5 for high contrast. If the content
+// on a container could then no longer keep its standard contrast ratio, the
+// container moves past the middle tones and the content switches between dark
+// and light, as in Material, so the contrast ratio of the content on a container
+// never decreases with the contrast level. The tones of the surface, surface container, and fixed colors are
+// not adjusted. The standard tones of the primary, secondary, and tertiary
+// colors depend on the variant of the palette (see [Key.Variant]).
+func NewLightSchemeContrast(p *Palette, level float32) Scheme {
+	ct := func(std, bg int, curve contrastCurve) int {
+		return contrastTone(std, bg, curve, level)
+	}
+	pt, st, tt := p.accentTones(false)
 	s := Scheme{
-		Primary:   NewAccentLight(p.Primary),
-		Secondary: NewAccentLight(p.Secondary),
-		Tertiary:  NewAccentLight(p.Tertiary),
-		Select:    NewAccentLight(p.Select),
-		Error:     NewAccentLight(p.Error),
-		Success:   NewAccentLight(p.Success),
-		Warn:      NewAccentLight(p.Warn),
+		Primary:   newAccent(p.Primary, 98, pt.base, pt.on, pt.container, pt.onContainer, level),
+		Secondary: newAccent(p.Secondary, 98, st.base, st.on, st.container, st.onContainer, level),
+		Tertiary:  newAccent(p.Tertiary, 98, tt.base, tt.on, tt.container, tt.onContainer, level),
+		Select:    NewAccentLightContrast(p.Select, level),
+		Error:     NewAccentLightContrast(p.Error, level),
+		Success:   NewAccentLightContrast(p.Success, level),
+		Warn:      NewAccentLightContrast(p.Warn, level),
 		Custom:    map[string]Accent{},
 
 		SurfaceDim:    p.Neutral.AbsTone(87),
@@ -160,41 +188,68 @@ func NewLightScheme(p *Palette) Scheme {
 		SurfaceContainerHighest: p.Neutral.AbsTone(90),
 
 		SurfaceVariant:   p.NeutralVariant.AbsTone(90),
-		OnSurface:        p.NeutralVariant.AbsTone(10),
-		OnSurfaceVariant: p.NeutralVariant.AbsTone(30),
+		OnSurface:        p.NeutralVariant.AbsTone(ct(10, 98, onCurve)),
+		OnSurfaceVariant: p.NeutralVariant.AbsTone(ct(30, 98, variantCurve)),
 
 		InverseSurface:   p.Neutral.AbsTone(20),
-		InverseOnSurface: p.Neutral.AbsTone(95),
-		InversePrimary:   p.Primary.AbsTone(80),
+		InverseOnSurface: p.Neutral.AbsTone(ct(95, 20, onCurve)),
+		InversePrimary:   p.Primary.AbsTone(ct(80, 20, accentCurve)),
 
 		Background:   p.Neutral.AbsTone(98),
-		OnBackground: p.Neutral.AbsTone(10),
+		OnBackground: p.Neutral.AbsTone(ct(10, 98, onCurve)),
 
-		Outline:        p.NeutralVariant.AbsTone(50),
-		OutlineVariant: p.NeutralVariant.AbsTone(80),
+		Outline:        p.NeutralVariant.AbsTone(ct(50, 98, outlineCurve)),
+		OutlineVariant: p.NeutralVariant.AbsTone(ct(80, 98, outlineVariantCurve)),
 
 		Shadow:      p.Neutral.AbsTone(0),
 		SurfaceTint: p.Primary.AbsTone(40),
 		Scrim:       p.Neutral.AbsTone(0),
+
+		PrimaryFixed:          p.Primary.AbsTone(pt.fixed),
+		PrimaryFixedDim:       p.Primary.AbsTone(pt.fixedDim),
+		OnPrimaryFixed:        p.Primary.AbsTone(ct(pt.onFixed, pt.fixedDim, onCurve)),
+		OnPrimaryFixedVariant: p.Primary.AbsTone(ct(pt.onFixedVariant, pt.fixedDim, variantCurve)),
+
+		SecondaryFixed:          p.Secondary.AbsTone(st.fixed),
+		SecondaryFixedDim:       p.Secondary.AbsTone(st.fixedDim),
+		OnSecondaryFixed:        p.Secondary.AbsTone(ct(st.onFixed, st.fixedDim, onCurve)),
+		OnSecondaryFixedVariant: p.Secondary.AbsTone(ct(st.onFixedVariant, st.fixedDim, variantCurve)),
+
+		TertiaryFixed:          p.Tertiary.AbsTone(tt.fixed),
+		TertiaryFixedDim:       p.Tertiary.AbsTone(tt.fixedDim),
+		OnTertiaryFixed:        p.Tertiary.AbsTone(ct(tt.onFixed, tt.fixedDim, onCurve)),
+		OnTertiaryFixedVariant: p.Tertiary.AbsTone(ct(tt.onFixedVariant, tt.fixedDim, variantCurve)),
 	}
 	for nm, c := range p.Custom {
-		s.Custom[nm] = NewAccentLight(c)
+		s.Custom[nm] = NewAccentLightContrast(c, level)
 	}
 	return s
-	// TODO: maybe fixed colors
 }
 
 // NewDarkScheme returns a new dark-themed [Scheme]
 // based on the given [Palette].
 func NewDarkScheme(p *Palette) Scheme {
+	return NewDarkSchemeContrast(p, ContrastStandard)
+}
+
+// NewDarkSchemeContrast returns a new dark-themed [Scheme] based on the
+// given [Palette] with the given contrast level, which ranges from -1
+// ([ContrastReduced]) to 1 ([ContrastHigh]), with 0 ([ContrastStandard])
+// resulting in the same scheme as [NewDarkScheme]. See [NewLightSchemeContrast]
+// for more information.
+func NewDarkSchemeContrast(p *Palette, level float32) Scheme {
+	ct := func(std, bg int, curve contrastCurve) int {
+		return contrastTone(std, bg, curve, level)
+	}
+	pt, st, tt := p.accentTones(true)
 	s := Scheme{
-		Primary:   NewAccentDark(p.Primary),
-		Secondary: NewAccentDark(p.Secondary),
-		Tertiary:  NewAccentDark(p.Tertiary),
-		Select:    NewAccentDark(p.Select),
-		Error:     NewAccentDark(p.Error),
-		Success:   NewAccentDark(p.Success),
-		Warn:      NewAccentDark(p.Warn),
+		Primary:   newAccent(p.Primary, 6, pt.base, pt.on, pt.container, pt.onContainer, level),
+		Secondary: newAccent(p.Secondary, 6, st.base, st.on, st.container, st.onContainer, level),
+		Tertiary:  newAccent(p.Tertiary, 6, tt.base, tt.on, tt.container, tt.onContainer, level),
+		Select:    NewAccentDarkContrast(p.Select, level),
+		Error:     NewAccentDarkContrast(p.Error, level),
+		Success:   NewAccentDarkContrast(p.Success, level),
+		Warn:      NewAccentDarkContrast(p.Warn, level),
 		Custom:    map[string]Accent{},
 
 		SurfaceDim:    p.Neutral.AbsTone(6),
@@ -208,28 +263,42 @@ func NewDarkScheme(p *Palette) Scheme {
 		SurfaceContainerHighest: p.Neutral.AbsTone(22),
 
 		SurfaceVariant:   p.NeutralVariant.AbsTone(30),
-		OnSurface:        p.NeutralVariant.AbsTone(90),
-		OnSurfaceVariant: p.NeutralVariant.AbsTone(80),
+		OnSurface:        p.NeutralVariant.AbsTone(ct(90, 6, onCurve)),
+		OnSurfaceVariant: p.NeutralVariant.AbsTone(ct(80, 6, variantCurve)),
 
 		InverseSurface:   p.Neutral.AbsTone(90),
-		InverseOnSurface: p.Neutral.AbsTone(20),
-		InversePrimary:   p.Primary.AbsTone(40),
+		InverseOnSurface: p.Neutral.AbsTone(ct(20, 90, onCurve)),
+		InversePrimary:   p.Primary.AbsTone(ct(40, 90, accentCurve)),
 
 		Background:   p.Neutral.AbsTone(6),
-		OnBackground: p.Neutral.AbsTone(90),
+		OnBackground: p.Neutral.AbsTone(ct(90, 6, onCurve)),
 
-		Outline:        p.NeutralVariant.AbsTone(60),
-		OutlineVariant: p.NeutralVariant.AbsTone(30),
+		Outline:        p.NeutralVariant.AbsTone(ct(60, 6, outlineCurve)),
+		OutlineVariant: p.NeutralVariant.AbsTone(ct(30, 6, outlineVariantCurve)),
 
 		Shadow:      p.Neutral.AbsTone(0),
 		SurfaceTint: p.Primary.AbsTone(80),
 		Scrim:       p.Neutral.AbsTone(0),
+
+		PrimaryFixed:          p.Primary.AbsTone(pt.fixed),
+		PrimaryFixedDim:       p.Primary.AbsTone(pt.fixedDim),
+		OnPrimaryFixed:        p.Primary.AbsTone(ct(pt.onFixed, pt.fixedDim, onCurve)),
+		OnPrimaryFixedVariant: p.Primary.AbsTone(ct(pt.onFixedVariant, pt.fixedDim, variantCurve)),
+
+		SecondaryFixed:          p.Secondary.AbsTone(st.fixed),
+		SecondaryFixedDim:       p.Secondary.AbsTone(st.fixedDim),
+		OnSecondaryFixed:        p.Secondary.AbsTone(ct(st.onFixed, st.fixedDim, onCurve)),
+		OnSecondaryFixedVariant: p.Secondary.AbsTone(ct(st.onFixedVariant, st.fixedDim, variantCurve)),
+
+		TertiaryFixed:          p.Tertiary.AbsTone(tt.fixed),
+		TertiaryFixedDim:       p.Tertiary.AbsTone(tt.fixedDim),
+		OnTertiaryFixed:        p.Tertiary.AbsTone(ct(tt.onFixed, tt.fixedDim, onCurve)),
+		OnTertiaryFixedVariant: p.Tertiary.AbsTone(ct(tt.onFixedVariant, tt.fixedDim, variantCurve)),
 	}
 	for nm, c := range p.Custom {
-		s.Custom[nm] = NewAccentDark(c)
+		s.Custom[nm] = NewAccentDarkContrast(c, level)
 	}
 	return s
-	// TODO: custom and fixed colors
 }
 
 // SchemeIsDark is whether the currently active color scheme
@@ -15,8 +15,15 @@ type Schemes struct {
 // NewSchemes returns new [Schemes] for the given
 // [Palette] containing both light and dark schemes.
 func NewSchemes(p *Palette) *Schemes {
+	return NewSchemesContrast(p, ContrastStandard)
+}
+
+// NewSchemesContrast returns new [Schemes] for the given [Palette]
+// containing both light and dark schemes with the given contrast
+// level (see [NewLightSchemeContrast]).
+func NewSchemesContrast(p *Palette, level float32) *Schemes {
 	return &Schemes{
-		Light: NewLightScheme(p),
-		Dark:  NewDarkScheme(p),
+		Light: NewLightSchemeContrast(p, level),
+		Dark:  NewDarkSchemeContrast(p, level),
 	}
 }
@@ -0,0 +1,154 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/score/score.dart
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcolor
+
+import (
+	"image"
+	"image/color"
+	"sort"
+
+	"goki.dev/cam/hct"
+	"goki.dev/colors/quantize"
+	"goki.dev/mat32/v2"
+)
+
+const (
+	// the chroma that source colors are ideally closest to
+	scoreTargetChroma = 48
+
+	// the weight of the proportion of the image with a similar hue in the score
+	scoreWeightProportion = 0.7
+
+	// the weight of chroma above the target chroma in the score
+	scoreWeightChromaAbove = 0.3
+
+	// the weight of chroma below the target chroma in the score
+	scoreWeightChromaBelow = 0.1
+
+	// the minimum chroma of source colors when filtering
+	scoreCutoffChroma = 5
+
+	// the minimum proportion of the image with a similar hue when filtering
+	scoreCutoffExcitedProportion = 0.01
+)
+
+// FallbackSourceColor is the source color returned by [Score] when there are
+// no suitable source colors, which is Google Blue (#4285f4), as in Material.
+var FallbackSourceColor = color.RGBA{66, 133, 244, 255}
+
+// Score returns up to the given number of source colors for color schemes
+// (see [KeyFromPrimary]) from the given map of colors to their populations,
+// as returned by a quantizer (see [quantize.Palette.Map]), ranked from most to
+// least suitable. Colors are scored by both the proportion of the population
+// that has a similar hue and their chroma, and the chosen colors have hues
+// that are as different as possible. If filter is true, colors with very low
+// chroma or a very small proportion are not used, and [FallbackSourceColor]
+// is returned if no colors are suitable.
+func Score(populations map[color.RGBA]int, desired int, filter bool) []color.RGBA {
+	var hcts []hct.HCT
+	var huePop [360]float32
+	total := float32(0)
+	for c, n := range populations {
+		h := hct.FromColor(c)
+		hcts = append(hcts, h)
+		huePop[int(mat32.Floor(h.Hue))%360] += float32(n)
+		total += float32(n)
+	}
+	// the proportion of the population that has a similar hue to each hue
+	var excited [360]float32
+	for hue := 0; hue < 360; hue++ {
+		p := huePop[hue] / total
+		for i := hue - 14; i < hue+16; i++ {
+			excited[(i+360)%360] += p
+		}
+	}
+
+	type scored struct {
+		hct   hct.HCT
+		score float32
+	}
+	var scs []scored
+	for _, h := range hcts {
+		p := excited[int(mat32.Round(h.Hue))%360]
+		if filter && (h.Chroma < scoreCutoffChroma || p <= scoreCutoffExcitedProportion) {
+			continue
+		}
+		cw := float32(scoreWeightChromaAbove)
+		if h.Chroma < scoreTargetChroma {
+			cw = scoreWeightChromaBelow
+		}
+		scs = append(scs, scored{h, p*100*scoreWeightProportion + (h.Chroma-scoreTargetChroma)*cw})
+	}
+	sort.Slice(scs, func(i, j int) bool {
+		if scs[i].score != scs[j].score {
+			return scs[i].score > scs[j].score
+		}
+		// break ties deterministically, as map iteration order is random
+		a, b := scs[i].hct, scs[j].hct
+		if a.Hue != b.Hue {
+			return a.Hue < b.Hue
+		}
+		return a.Tone < b.Tone
+	})
+
+	// choose the highest scoring colors with the largest
+	// possible difference in hue between them
+	var chosen []hct.HCT
+	for diff := float32(90); diff >= 15; diff-- {
+		chosen = chosen[:0]
+		for _, sc := range scs {
+			dup := false
+			for _, c := range chosen {
+				if mat32.Abs(hct.MinHueDistance(sc.hct.Hue, c.Hue)) < diff {
+					dup = true
+					break
+				}
+			}
+			if !dup {
+				chosen = append(chosen, sc.hct)
+			}
+			if len(chosen) >= desired {
+				break
+			}
+		}
+		if len(chosen) >= desired {
+			break
+		}
+	}
+	if len(chosen) == 0 {
+		return []color.RGBA{FallbackSourceColor}
+	}
+	res := make([]color.RGBA, len(chosen))
+	for i, c := range chosen {
+		res[i] = c.AsRGBA()
+	}
+	return res
+}
+
+// SourceColorsFromImage returns up to the given number of source colors for
+// color schemes (see [KeyFromPrimary]) from the given image, ranked from most
+// to least suitable, as in Material dynamic color. The image is sampled to
+// about 128x128 pixels, quantized into 128 colors (see [quantize.Celebi]), and
+// then scored (see [Score]). The first color is typically used as the primary
+// color of the scheme, and the others can be offered as alternatives.
+func SourceColorsFromImage(img image.Image, n int) []color.RGBA {
+	px := quantize.Pixels(img, 128*128)
+	return Score(quantize.Celebi(px, 128).Map(), n, true)
+}
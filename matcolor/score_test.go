@@ -0,0 +1,56 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcolor
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+// the reference values are from the tests of the Material color utilities
+func TestScore(t *testing.T) {
+	red, green, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 255, 0, 255}, color.RGBA{0, 0, 255, 255}
+	for _, tc := range []struct {
+		pops    map[color.RGBA]int
+		desired int
+		want    []color.RGBA
+	}{
+		{map[color.RGBA]int{{0, 0, 0, 255}: 1}, 4, []color.RGBA{FallbackSourceColor}},
+		{map[color.RGBA]int{red: 1, green: 1, blue: 1}, 4, []color.RGBA{red, green, blue}},
+		{map[color.RGBA]int{red: 1, green: 1, blue: 1}, 1, []color.RGBA{red}},
+		{map[color.RGBA]int{red: 1, green: 1, blue: 2}, 1, []color.RGBA{blue}},
+		{map[color.RGBA]int{{0x00, 0x87, 0x72, 255}: 1, {0x31, 0x84, 0x77, 255}: 1}, 4, []color.RGBA{{0x00, 0x87, 0x72, 255}}},
+		{map[color.RGBA]int{{0x00, 0x87, 0x72, 255}: 1, {0x00, 0x85, 0x87, 255}: 1, {0x00, 0x7e, 0xbc, 255}: 1}, 2, []color.RGBA{{0x00, 0x7e, 0xbc, 255}, {0x00, 0x87, 0x72, 255}}},
+	} {
+		if have := Score(tc.pops, tc.desired, true); !slices.Equal(have, tc.want) {
+			t.Errorf("expected %v for %v but got %v", tc.want, tc.pops, have)
+		}
+	}
+}
+
+func TestSourceColorsFromImage(t *testing.T) {
+	// an image that is mostly orange with some blue and gray
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.RGBA{230, 120, 20, 255}
+			if x >= 150 {
+				c = color.RGBA{30, 60, 200, 255}
+			} else if y >= 90 {
+				c = color.RGBA{128, 128, 128, 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	cs := SourceColorsFromImage(img, 4)
+	if len(cs) != 2 {
+		t.Fatalf("expected 2 source colors but got %v", cs)
+	}
+	if cs[0] != (color.RGBA{230, 120, 20, 255}) || cs[1] != (color.RGBA{30, 60, 200, 255}) {
+		t.Errorf("expected orange and then blue source colors but got %v", cs)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcolor
+
+import (
+	"image"
+	"image/color"
+
+	"goki.dev/cam/hct"
+	"goki.dev/colors/quantize"
+	"goki.dev/mat32/v2"
+)
+
+// Swatch is a representative color of an image, with the number of pixels
+// that it represents and suggested colors for content on top of it.
+type Swatch struct {
+
+	// the color of the swatch
+	Color color.RGBA
+
+	// the number of pixels of the image represented by the swatch
+	Population int
+
+	// the color applied to body text and icons on top of the swatch, which
+	// has the same hue and a contrast ratio of at least 4.5 with it
+	On color.RGBA
+
+	// the color applied to title text on top of the swatch, which has
+	// the same hue and a contrast ratio of at least 3 with it
+	OnVariant color.RGBA
+}
+
+// Swatches contains the representative colors of an image, as in the Android
+// Palette API. The vibrant swatches have a high chroma and the muted swatches
+// have a low chroma, each with a light, medium, and dark tone. Each color
+// of the image is used for at most one of the vibrant and muted swatches,
+// and any of them may be nil if the image has no suitable color.
+type Swatches struct {
+
+	// the most common color of the image that is not nearly black or white
+	// (with a tone from 5 to 95), as in Android, so it is not necessarily
+	// the most common color of the image
+	Dominant *Swatch
+
+	// the color with a high chroma and a medium tone
+	Vibrant *Swatch
+
+	// the color with a high chroma and a dark tone
+	DarkVibrant *Swatch
+
+	// the color with a high chroma and a light tone
+	LightVibrant *Swatch
+
+	// the color with a low chroma and a medium tone
+	Muted *Swatch
+
+	// the color with a low chroma and a dark tone
+	DarkMuted *Swatch
+
+	// the color with a low chroma and a light tone
+	LightMuted *Swatch
+}
+
+// swatchTarget contains the ranges and target values of the tone and
+// chroma of a kind of swatch.
+type swatchTarget struct {
+	minTone, tone, maxTone       float32
+	minChroma, chroma, maxChroma float32
+}
+
+const (
+	// the weights of the tone, chroma, and population in the score of a swatch
+	swatchWeightTone       = 0.52
+	swatchWeightChroma     = 0.24
+	swatchWeightPopulation = 0.24
+
+	// colors with a tone outside of this range are not used for swatches,
+	// as they are too close to black or white
+	swatchMinTone = 5
+	swatchMaxTone = 95
+)
+
+// the swatch targets, which correspond to the lightness and saturation
+// targets of the Android Palette API in HCT
+var (
+	lightVibrantTarget = swatchTarget{55, 74, 100, 32, 80, 200}
+	vibrantTarget      = swatchTarget{30, 50, 70, 32, 80, 200}
+	darkVibrantTarget  = swatchTarget{0, 26, 45, 32, 80, 200}
+	lightMutedTarget   = swatchTarget{55, 74, 100, 0, 12, 28}
+	mutedTarget        = swatchTarget{30, 50, 70, 0, 12, 28}
+	darkMutedTarget    = swatchTarget{0, 26, 45, 0, 12, 28}
+)
+
+// SwatchesFromImage returns the [Swatches] of the given image. The image is
+// sampled to about 128x128 pixels and quantized into 16 colors (see
+// [quantize.Celebi]), from which the swatches are chosen (see [NewSwatches]).
+func SwatchesFromImage(img image.Image) *Swatches {
+	px := quantize.Pixels(img, 128*128)
+	return NewSwatches(quantize.Celebi(px, 16))
+}
+
+// NewSwatches returns the [Swatches] chosen from the given quantized palette
+// of an image. Each swatch is the color that is within the tone and chroma
+// ranges of the swatch and has the highest score, which is based on how
+// close its tone and chroma are to the targets for the swatch and its
+// population relative to the most common color. Colors that are nearly
+// black or white (with a tone below 5 or above 95) are not used for any of
+// the swatches, including the dominant swatch, which is the most common of
+// the remaining colors.
+func NewSwatches(p *quantize.Palette) *Swatches {
+	var cs []color.RGBA
+	var hcts []hct.HCT
+	var pops []int
+	maxPop := 0
+	for i, c := range p.Colors {
+		h := hct.FromColor(c)
+		if h.Tone < swatchMinTone || h.Tone > swatchMaxTone {
+			continue
+		}
+		cs = append(cs, c.(color.RGBA))
+		hcts = append(hcts, h)
+		pops = append(pops, p.Counts[i])
+		maxPop = max(maxPop, p.Counts[i])
+	}
+	s := &Swatches{}
+	if len(hcts) == 0 {
+		return s
+	}
+	dom := 0
+	for i, n := range pops {
+		if n > pops[dom] {
+			dom = i
+		}
+	}
+	s.Dominant = newSwatch(cs[dom], hcts[dom], pops[dom])
+
+	used := make([]bool, len(hcts))
+	find := func(t swatchTarget) *Swatch {
+		best := -1
+		bestScore := float32(0)
+		for i, h := range hcts {
+			if used[i] || h.Tone < t.minTone || h.Tone > t.maxTone || h.Chroma < t.minChroma || h.Chroma > t.maxChroma {
+				continue
+			}
+			score := swatchWeightTone*(1-mat32.Abs(h.Tone-t.tone)/100) +
+				swatchWeightChroma*(1-mat32.Min(mat32.Abs(h.Chroma-t.chroma)/100, 1)) +
+				swatchWeightPopulation*float32(pops[i])/float32(maxPop)
+			if best < 0 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			return nil
+		}
+		used[best] = true
+		return newSwatch(cs[best], hcts[best], pops[best])
+	}
+	// the order is the same as in Android, such that
+	// the vibrant swatches are chosen first
+	s.LightVibrant = find(lightVibrantTarget)
+	s.Vibrant = find(vibrantTarget)
+	s.DarkVibrant = find(darkVibrantTarget)
+	s.LightMuted = find(lightMutedTarget)
+	s.Muted = find(mutedTarget)
+	s.DarkMuted = find(darkMutedTarget)
+	return s
+}
+
+// newSwatch returns a new [Swatch] for the given color,
+// which is h in HCT, and the given population.
+func newSwatch(c color.RGBA, h hct.HCT, pop int) *Swatch {
+	return &Swatch{
+		Color:      c,
+		Population: pop,
+		On:         h.WithTone(hct.ContrastTone(h.Tone, 4.5)).AsRGBA(),
+		OnVariant:  h.WithTone(hct.ContrastTone(h.Tone, 3)).AsRGBA(),
+	}
+}
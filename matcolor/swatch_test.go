@@ -0,0 +1,92 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcolor
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"goki.dev/cam/hct"
+	"goki.dev/colors/quantize"
+)
+
+func TestSwatches(t *testing.T) {
+	vibrant := color.RGBA{230, 40, 40, 255}
+	darkVibrant := color.RGBA{20, 40, 140, 255}
+	lightVibrant := color.RGBA{120, 230, 120, 255}
+	muted := color.RGBA{120, 110, 100, 255}
+	darkMuted := color.RGBA{50, 55, 60, 255}
+	lightMuted := color.RGBA{200, 195, 205, 255}
+	p := &quantize.Palette{
+		Colors: color.Palette{muted, vibrant, darkVibrant, lightVibrant, darkMuted, lightMuted, color.RGBA{255, 255, 255, 255}},
+		Counts: []int{60, 40, 30, 20, 10, 5, 100},
+	}
+	s := NewSwatches(p)
+	for _, tc := range []struct {
+		name string
+		sw   *Swatch
+		want color.RGBA
+	}{
+		// white is more common, but it is not used for swatches
+		{"Dominant", s.Dominant, muted},
+		{"Vibrant", s.Vibrant, vibrant},
+		{"DarkVibrant", s.DarkVibrant, darkVibrant},
+		{"LightVibrant", s.LightVibrant, lightVibrant},
+		{"Muted", s.Muted, muted},
+		{"DarkMuted", s.DarkMuted, darkMuted},
+		{"LightMuted", s.LightMuted, lightMuted},
+	} {
+		if tc.sw == nil {
+			t.Errorf("%s: expected %v but got nil", tc.name, tc.want)
+			continue
+		}
+		if tc.sw.Color != tc.want {
+			t.Errorf("%s: expected %v but got %v", tc.name, tc.want, tc.sw.Color)
+		}
+		if r := hct.ContrastRatio(tc.sw.Color, tc.sw.On); r < 4.4 {
+			t.Errorf("%s: expected On contrast of at least 4.5 but got %g", tc.name, r)
+		}
+		if r := hct.ContrastRatio(tc.sw.Color, tc.sw.OnVariant); r < 2.9 {
+			t.Errorf("%s: expected OnVariant contrast of at least 3 but got %g", tc.name, r)
+		}
+	}
+	if s.Muted.Population != 60 {
+		t.Errorf("expected muted population of 60 but got %d", s.Muted.Population)
+	}
+
+	// only vibrant colors
+	s = NewSwatches(&quantize.Palette{Colors: color.Palette{vibrant}, Counts: []int{1}})
+	if s.Vibrant == nil || s.Muted != nil || s.DarkVibrant != nil {
+		t.Errorf("expected only a vibrant swatch but got %+v", s)
+	}
+	if s := NewSwatches(&quantize.Palette{}); s.Dominant != nil {
+		t.Errorf("expected no swatches but got %+v", s)
+	}
+}
+
+func TestSwatchesFromImage(t *testing.T) {
+	// an image that is mostly dark gray with an orange stripe
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			c := color.RGBA{50, 50, 55, 255}
+			if y < 20 {
+				c = color.RGBA{210, 80, 10, 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	s := SwatchesFromImage(img)
+	if s.Dominant == nil || s.Dominant.Color != (color.RGBA{50, 50, 55, 255}) || s.Dominant.Population != 8000 {
+		t.Errorf("expected dark gray dominant swatch but got %+v", s.Dominant)
+	}
+	if s.Vibrant == nil || s.Vibrant.Color != (color.RGBA{210, 80, 10, 255}) {
+		t.Errorf("expected orange vibrant swatch but got %+v", s.Vibrant)
+	}
+	if s.DarkMuted == nil || s.DarkMuted.Color != s.Dominant.Color {
+		t.Errorf("expected dark gray dark muted swatch but got %+v", s.DarkMuted)
+	}
+}
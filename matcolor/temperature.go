@@ -0,0 +1,205 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/temperature/temperature_cache.dart
+// and https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/dislike/dislike_analyzer.dart
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcolor
+
+import (
+	"sort"
+
+	"goki.dev/cam/cie"
+	"goki.dev/cam/hct"
+	"goki.dev/mat32/v2"
+)
+
+// Temperature contains the colors of all hues at the chroma and tone of an
+// input color, sorted by their color temperature, which is used for finding
+// complementary and analogous colors based on the color theory concept of
+// warm and cool colors. Use [NewTemperature] to create a new one.
+type Temperature struct {
+
+	// the input color
+	Input hct.HCT
+
+	// the colors at each integer hue from 0 to 360 with the chroma and tone of the input color
+	ByHue []hct.HCT
+
+	// the colors of ByHue and the input color, sorted by their raw temperature
+	ByTemp []hct.HCT
+}
+
+// NewTemperature returns a new [Temperature] for the given input color.
+func NewTemperature(input hct.HCT) *Temperature {
+	t := &Temperature{Input: input}
+	t.ByHue = make([]hct.HCT, 361)
+	for i := range t.ByHue {
+		t.ByHue[i] = hct.New(float32(i), input.Chroma, input.Tone)
+	}
+	t.ByTemp = append([]hct.HCT{}, t.ByHue...)
+	t.ByTemp = append(t.ByTemp, input)
+	sort.SliceStable(t.ByTemp, func(i, j int) bool {
+		return RawTemperature(t.ByTemp[i]) < RawTemperature(t.ByTemp[j])
+	})
+	return t
+}
+
+// RawTemperature returns the color temperature of the given color, which
+// is typically from -0.5 to 3, with warm colors having higher temperatures.
+// It is based on the hue and chroma of the color in the L*a*b* colorspace.
+func RawTemperature(c hct.HCT) float32 {
+	x, y, z := cie.SRGBToXYZ(c.R, c.G, c.B)
+	_, a, b := cie.XYZToLAB(x, y, z)
+	hue := sanitizeDegrees(mat32.RadToDeg(mat32.Atan2(b, a)))
+	chroma := mat32.Sqrt(a*a + b*b)
+	return -0.5 + 0.02*mat32.Pow(chroma, 1.07)*mat32.Cos(mat32.DegToRad(sanitizeDegrees(hue-50)))
+}
+
+// Coldest returns the coldest color with the chroma and tone of the input color.
+func (t *Temperature) Coldest() hct.HCT {
+	return t.ByTemp[0]
+}
+
+// Warmest returns the warmest color with the chroma and tone of the input color.
+func (t *Temperature) Warmest() hct.HCT {
+	return t.ByTemp[len(t.ByTemp)-1]
+}
+
+// Relative returns the temperature of the given color relative to the
+// coldest (0) and warmest (1) colors with the chroma and tone of the input color.
+func (t *Temperature) Relative(c hct.HCT) float32 {
+	cold := RawTemperature(t.Coldest())
+	rng := RawTemperature(t.Warmest()) - cold
+	if rng == 0 {
+		return 0.5
+	}
+	return (RawTemperature(c) - cold) / rng
+}
+
+// Complement returns the color that is the opposite of the input color in
+// temperature, which is not necessarily the opposite of it in hue.
+func (t *Temperature) Complement() hct.HCT {
+	coldHue, coldTemp := t.Coldest().Hue, RawTemperature(t.Coldest())
+	warmHue, warmTemp := t.Warmest().Hue, RawTemperature(t.Warmest())
+	rng := warmTemp - coldTemp
+	startHue, endHue := coldHue, warmHue
+	if isBetween(t.Input.Hue, coldHue, warmHue) {
+		startHue, endHue = warmHue, coldHue
+	}
+	smallest := float32(1000)
+	answer := t.ByHue[int(mat32.Round(t.Input.Hue))]
+	complement := 1 - t.Relative(t.Input)
+	for add := float32(0); add <= 360; add++ {
+		hue := sanitizeDegrees(startHue + add)
+		if !isBetween(hue, startHue, endHue) {
+			continue
+		}
+		possible := t.ByHue[int(mat32.Round(hue))]
+		err := mat32.Abs(complement - (RawTemperature(possible)-coldTemp)/rng)
+		if err < smallest {
+			smallest = err
+			answer = possible
+		}
+	}
+	return answer
+}
+
+// Analogous returns the given number of colors that are analogous to the
+// input color, which are evenly spaced in temperature when the hue wheel is
+// divided into the given number of divisions. The input color is in the
+// middle of the returned colors, with the colors before it going
+// counterclockwise on the hue wheel and the colors after it going clockwise.
+// Material uses a count of 5 and 12 divisions by default.
+func (t *Temperature) Analogous(count, divisions int) []hct.HCT {
+	startHue := int(mat32.Round(t.Input.Hue))
+	start := t.ByHue[startHue]
+	last := t.Relative(start)
+	all := []hct.HCT{start}
+
+	total := float32(0)
+	for i := 0; i < 360; i++ {
+		temp := t.Relative(t.ByHue[(startHue+i)%360])
+		total += mat32.Abs(temp - last)
+		last = temp
+	}
+	step := total / float32(divisions)
+	sum := float32(0)
+	last = t.Relative(start)
+	for add := 1; len(all) < divisions; add++ {
+		c := t.ByHue[(startHue+add)%360]
+		temp := t.Relative(c)
+		sum += mat32.Abs(temp - last)
+		for idx := 1; len(all) < divisions && sum >= float32(len(all)+idx-1)*step; idx++ {
+			all = append(all, c)
+		}
+		last = temp
+		if add >= 360 {
+			for len(all) < divisions {
+				all = append(all, c)
+			}
+		}
+	}
+
+	n := len(all)
+	ccw := (count - 1) / 2
+	res := make([]hct.HCT, 0, count)
+	for i := ccw; i >= 1; i-- {
+		res = append(res, all[((-i)%n+n)%n])
+	}
+	res = append(res, t.Input)
+	for i := 1; i <= count-ccw-1; i++ {
+		res = append(res, all[i%n])
+	}
+	return res
+}
+
+// IsDisliked returns whether the given color is in the range of dark,
+// yellow-green colors that are universally disliked, as found in
+// research on color preferences.
+func IsDisliked(c hct.HCT) bool {
+	hue, chroma, tone := mat32.Round(c.Hue), mat32.Round(c.Chroma), mat32.Round(c.Tone)
+	return hue >= 90 && hue <= 111 && chroma > 16 && tone < 65
+}
+
+// FixIfDisliked returns a lighter version of the given color with
+// a tone of 70 if it is disliked (see [IsDisliked]), and the given
+// color otherwise.
+func FixIfDisliked(c hct.HCT) hct.HCT {
+	if IsDisliked(c) {
+		return hct.New(c.Hue, c.Chroma, 70)
+	}
+	return c
+}
+
+// sanitizeDegrees returns the given angle in degrees wrapped into the range 0-360.
+func sanitizeDegrees(deg float32) float32 {
+	deg = mat32.Mod(deg, 360)
+	if deg < 0 {
+		deg += 360
+	}
+	return deg
+}
+
+// isBetween returns whether the given angle in degrees is between
+// the given angles, going clockwise from a to b.
+func isBetween(angle, a, b float32) bool {
+	if a < b {
+		return a <= angle && angle <= b
+	}
+	return a <= angle || angle <= b
+}
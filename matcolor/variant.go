@@ -0,0 +1,292 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/scheme
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matcolor
+
+import (
+	"image/color"
+
+	"goki.dev/cam/hct"
+	"goki.dev/mat32/v2"
+)
+
+// Variants are the different variants of Material Design 3 dynamic color
+// schemes, which determine how the key colors of a scheme are derived
+// from a source color (see [KeyFromPrimaryVariant]).
+type Variants int32 //enums:enum
+
+const (
+	// TonalSpot is the default Material variant, which results in
+	// a calm scheme with a moderately colorful primary color and
+	// muted secondary and tertiary colors.
+	TonalSpot Variants = iota
+
+	// Vibrant results in a colorful scheme with the highest available
+	// chroma for the primary color and secondary and tertiary colors
+	// with hues that are rotated from the source color.
+	Vibrant
+
+	// Expressive results in a playful scheme in which the primary color
+	// does not have the hue of the source color.
+	Expressive
+
+	// Fidelity results in a scheme with a primary color that matches the
+	// source color, even if it is highly chromatic, and a tertiary color
+	// that is the complement of the source color in temperature.
+	Fidelity
+
+	// Content is like [Fidelity], but with a tertiary color that is
+	// analogous to the source color in temperature, which is designed for
+	// schemes based on the colors of content such as images.
+	Content
+
+	// Monochrome results in a grayscale scheme.
+	Monochrome
+
+	// Neutral results in a nearly grayscale scheme with a hint of
+	// the hue of the source color.
+	Neutral
+
+	// Rainbow results in a playful scheme with a colorful primary color
+	// and grayscale neutral colors.
+	Rainbow
+
+	// FruitSalad results in a playful scheme in which the primary and
+	// secondary colors have hues that are rotated from the source color.
+	FruitSalad
+)
+
+// KeyFromPrimaryVariant returns a new [Key] from the given primary source
+// color, with the primary, secondary, tertiary, neutral, and neutral variant
+// key colors derived from it as in the given Material Design 3 variant.
+// The other key colors are the same as those of [KeyFromPrimary], except
+// that the select key color has at most the chroma of the primary key color.
+// As in Material, the schemes based on the key use different tones for some
+// of the primary, secondary, and tertiary colors in the [Fidelity], [Content],
+// and [Monochrome] variants (see [Key.Variant]). For example, the primary
+// container color in the [Fidelity] and [Content] variants has the tone of
+// the source color, and the primary color in the [Monochrome] variant is
+// black in light schemes and white in dark schemes.
+func KeyFromPrimaryVariant(primary color.RGBA, variant Variants) *Key {
+	k := KeyFromPrimary(primary)
+	k.Variant = variant
+	k.Source = primary
+	s := hct.FromColor(primary)
+	h, c := s.Hue, s.Chroma
+	switch variant {
+	case TonalSpot:
+		k.Primary = keyColor(h, 36)
+		k.Secondary = keyColor(h, 16)
+		k.Tertiary = keyColor(h+60, 24)
+		k.Neutral = keyColor(h, 6)
+		k.NeutralVariant = keyColor(h, 8)
+	case Vibrant:
+		hues := []float32{0, 41, 61, 101, 131, 181, 251, 301, 360}
+		k.Primary = keyColor(h, 200)
+		k.Secondary = keyColor(rotateHue(h, hues, []float32{18, 15, 10, 12, 15, 18, 15, 12, 12}), 24)
+		k.Tertiary = keyColor(rotateHue(h, hues, []float32{35, 30, 20, 25, 30, 35, 30, 25, 25}), 32)
+		k.Neutral = keyColor(h, 10)
+		k.NeutralVariant = keyColor(h, 12)
+	case Expressive:
+		hues := []float32{0, 21, 51, 121, 151, 191, 271, 321, 360}
+		k.Primary = keyColor(h+240, 40)
+		k.Secondary = keyColor(rotateHue(h, hues, []float32{45, 95, 45, 20, 45, 90, 45, 45, 45}), 24)
+		k.Tertiary = keyColor(rotateHue(h, hues, []float32{120, 120, 20, 45, 20, 15, 20, 120, 120}), 32)
+		k.Neutral = keyColor(h+15, 8)
+		k.NeutralVariant = keyColor(h+15, 12)
+	case Fidelity, Content:
+		k.Primary = keyColor(h, c)
+		k.Secondary = keyColor(h, max(c-32, c*0.5))
+		var t hct.HCT
+		if variant == Fidelity {
+			t = NewTemperature(s).Complement()
+		} else {
+			t = NewTemperature(s).Analogous(3, 6)[2]
+		}
+		t = FixIfDisliked(t)
+		k.Tertiary = keyColor(t.Hue, t.Chroma)
+		k.Neutral = keyColor(h, c/8)
+		k.NeutralVariant = keyColor(h, c/8+4)
+	case Monochrome:
+		k.Primary = keyColor(h, 0)
+		k.Secondary = keyColor(h, 0)
+		k.Tertiary = keyColor(h, 0)
+		k.Neutral = keyColor(h, 0)
+		k.NeutralVariant = keyColor(h, 0)
+	case Neutral:
+		k.Primary = keyColor(h, 12)
+		k.Secondary = keyColor(h, 8)
+		k.Tertiary = keyColor(h, 16)
+		k.Neutral = keyColor(h, 2)
+		k.NeutralVariant = keyColor(h, 2)
+	case Rainbow:
+		k.Primary = keyColor(h, 48)
+		k.Secondary = keyColor(h, 16)
+		k.Tertiary = keyColor(h+60, 24)
+		k.Neutral = keyColor(h, 0)
+		k.NeutralVariant = keyColor(h, 0)
+	case FruitSalad:
+		k.Primary = keyColor(h-50, 48)
+		k.Secondary = keyColor(h-50, 36)
+		k.Tertiary = keyColor(h, 36)
+		k.Neutral = keyColor(h, 10)
+		k.NeutralVariant = keyColor(h, 16)
+	}
+	p := hct.FromColor(k.Primary)
+	k.Select = keyColor(p.Hue, min(p.Chroma, 24))
+	return k
+}
+
+// keyColor returns a key color with the given hue and chroma, which is the
+// color with the tone closest to 50 at which the chroma is available, or the
+// color with the highest available chroma for the hue if it is not available
+// at any tone. The tones generated from it (see [Tones]) then have the given
+// hue and chroma, as in a Material tonal palette.
+func keyColor(hue, chroma float32) color.RGBA {
+	hue = sanitizeDegrees(hue)
+	best := hct.New(hue, chroma, 50)
+	if best.Chroma >= chroma-0.5 {
+		return best.AsRGBA()
+	}
+	for d := float32(1); d < 50; d++ {
+		for _, tone := range []float32{50 - d, 50 + d} {
+			c := hct.New(hue, chroma, tone)
+			if c.Chroma >= chroma-0.5 {
+				return c.AsRGBA()
+			}
+			if c.Chroma > best.Chroma {
+				best = c
+			}
+		}
+	}
+	return best.AsRGBA()
+}
+
+// rotateHue returns the given hue rotated by the rotation for the range of
+// hues that it is in, as used in the [Vibrant] and [Expressive] variants.
+func rotateHue(hue float32, hues, rotations []float32) float32 {
+	hue = sanitizeDegrees(hue)
+	for i := 0; i < len(hues)-1; i++ {
+		if hue > hues[i] && hue < hues[i+1] {
+			return sanitizeDegrees(hue + rotations[i])
+		}
+	}
+	return hue
+}
+
+// accentTones contains the standard tones of the colors of an [Accent] and
+// of the fixed colors for an accent key color in a scheme.
+type accentTones struct {
+	base, on, container, onContainer         int
+	fixed, fixedDim, onFixed, onFixedVariant int
+}
+
+// accentTones returns the standard tones of the primary, secondary, and
+// tertiary colors in a light or dark scheme based on the palette, which
+// depend on the variant of the palette as in Material (see [Key.Variant]).
+func (p *Palette) accentTones(dark bool) (primary, secondary, tertiary accentTones) {
+	std := accentTones{40, 100, 90, 10, 90, 80, 10, 30}
+	if dark {
+		std = accentTones{80, 20, 30, 90, 90, 80, 10, 30}
+	}
+	primary, secondary, tertiary = std, std, std
+	switch p.Variant {
+	case Monochrome:
+		primary = accentTones{0, 90, 25, 100, 40, 30, 100, 90}
+		secondary = accentTones{40, 100, 85, 10, 80, 70, 10, 25}
+		tertiary = accentTones{25, 90, 49, 100, 40, 30, 100, 90}
+		if dark {
+			primary.base, primary.on, primary.container, primary.onContainer = 100, 10, 85, 0
+			secondary.base, secondary.on, secondary.container, secondary.onContainer = 80, 10, 30, 90
+			tertiary.base, tertiary.on, tertiary.container, tertiary.onContainer = 90, 10, 60, 0
+		}
+	case Fidelity, Content:
+		tone := int(mat32.Round(hct.FromColor(p.Source).Tone))
+		primary.container = tone
+		secondary.container = chromaTone(hct.FromColor(p.Secondary.Key), secondary.container, !dark)
+		tertiary.container = int(mat32.Round(FixIfDisliked(hct.FromColor(p.Tertiary.AbsTone(tone))).Tone))
+	}
+	for _, t := range []*accentTones{&primary, &secondary, &tertiary} {
+		t.container, t.base = separateTones(t.container, t.base, dark)
+		if p.Variant == Fidelity || p.Variant == Content {
+			t.onContainer = foregroundTone(t.container, 4.5)
+		}
+	}
+	return
+}
+
+// chromaTone returns the tone closest to the given tone at which the hue of
+// the given color has its chroma, moving to lower tones if decreasing is
+// true and to higher tones otherwise, and stopping at the tone with the
+// highest chroma if the chroma is not available, as in Material.
+func chromaTone(c hct.HCT, tone int, decreasing bool) int {
+	dir := 1
+	if decreasing {
+		dir = -1
+	}
+	closest := hct.New(c.Hue, c.Chroma, float32(tone))
+	peak := closest.Chroma
+	for closest.Chroma < c.Chroma && tone+dir >= 0 && tone+dir <= 100 {
+		tone += dir
+		h := hct.New(c.Hue, c.Chroma, float32(tone))
+		if h.Chroma < peak || mat32.Abs(h.Chroma-c.Chroma) < 0.4 {
+			break
+		}
+		if mat32.Abs(h.Chroma-c.Chroma) < mat32.Abs(closest.Chroma-c.Chroma) {
+			closest = h
+		}
+		peak = max(peak, h.Chroma)
+	}
+	return tone
+}
+
+// separateTones returns the given container and base tones of an accent
+// color adjusted such that the base tone is at least 10 farther from the
+// tone of the surface than the container tone, and such that neither of
+// them is in the range 50-59, which has poor contrast with both light and
+// dark content, as in the tone delta pairs of Material.
+func separateTones(container, base int, dark bool) (int, int) {
+	dir := -1
+	if dark {
+		dir = 1
+	}
+	if (base-container)*dir < 10 {
+		base = min(max(container+10*dir, 0), 100)
+		if (base-container)*dir < 10 {
+			container = min(max(base-10*dir, 0), 100)
+		}
+	}
+	switch {
+	case container >= 50 && container < 60:
+		if dark {
+			container = 60
+			base = max(base, container+10)
+		} else {
+			container = 49
+			base = min(base, container-10)
+		}
+	case base >= 50 && base < 60:
+		if dark {
+			base = 60
+		} else {
+			base = 49
+		}
+	}
+	return container, base
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matcolor
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/cam/hct"
+	"goki.dev/mat32/v2"
+)
+
+// expectColor checks that the given color is within 2 of the given
+// reference color in each channel, as there are small differences due
+// to the float32 precision of the HCT colorspace in this package.
+func expectColor(t *testing.T, name string, want uint32, have color.RGBA) {
+	t.Helper()
+	w := color.RGBA{uint8(want >> 16), uint8(want >> 8), uint8(want), 255}
+	d := func(a, b uint8) int {
+		if a > b {
+			return int(a - b)
+		}
+		return int(b - a)
+	}
+	if d(w.R, have.R) > 2 || d(w.G, have.G) > 2 || d(w.B, have.B) > 2 || have.A != 255 {
+		t.Errorf("%s: expected %v but got %v", name, w, have)
+	}
+}
+
+// the reference values are from the tests of the Material color utilities
+func TestTemperature(t *testing.T) {
+	blue := NewTemperature(hct.FromColor(color.RGBA{0, 0, 255, 255}))
+	red := NewTemperature(hct.FromColor(color.RGBA{255, 0, 0, 255}))
+	green := NewTemperature(hct.FromColor(color.RGBA{0, 255, 0, 255}))
+
+	for _, tc := range []struct {
+		tmp  *Temperature
+		want float32
+	}{{blue, -1.393}, {red, 2.351}, {green, -0.267}} {
+		if have := RawTemperature(tc.tmp.Input); mat32.Abs(have-tc.want) > 0.001 {
+			t.Errorf("expected raw temperature %g but got %g", tc.want, have)
+		}
+	}
+
+	expectColor(t, "blue complement", 0x9D0002, blue.Complement().AsRGBA())
+	expectColor(t, "red complement", 0x007BFC, red.Complement().AsRGBA())
+	expectColor(t, "green complement", 0xFFD2C9, green.Complement().AsRGBA())
+
+	for i, want := range []uint32{0x00590C, 0x00564E, 0x0000FF, 0x6700CC, 0x81009F} {
+		expectColor(t, "blue analogous", want, blue.Analogous(5, 12)[i].AsRGBA())
+	}
+	for i, want := range []uint32{0xF60082, 0xFC004C, 0xFF0000, 0xD95500, 0xAF7200} {
+		expectColor(t, "red analogous", want, red.Analogous(5, 12)[i].AsRGBA())
+	}
+
+	if !IsDisliked(hct.New(100, 50, 40)) || IsDisliked(FixIfDisliked(hct.New(100, 50, 40))) {
+		t.Errorf("expected dark yellow-green to be disliked and fixed")
+	}
+}
+
+func TestVariants(t *testing.T) {
+	blue := color.RGBA{0, 0, 255, 255}
+	s := NewLightScheme(NewPalette(KeyFromPrimaryVariant(blue, TonalSpot)))
+	expectColor(t, "tonal spot primary", 0x555992, s.Primary.Base)
+	expectColor(t, "tonal spot primary container", 0xE0E0FF, s.Primary.Container)
+	s = NewLightScheme(NewPalette(KeyFromPrimaryVariant(blue, Vibrant)))
+	expectColor(t, "vibrant primary", 0x343DFF, s.Primary.Base)
+
+	// the tones of the base, on, container, and on container colors of the
+	// primary, secondary, and tertiary accents in the light and dark schemes,
+	// which follow the specs of the Material variants; the tone of blue is 32,
+	// which is the tone of the primary container in Fidelity and Content
+	std := [2][3][4]float32{
+		{{40, 100, 90, 10}, {40, 100, 90, 10}, {40, 100, 90, 10}},
+		{{80, 20, 30, 90}, {80, 20, 30, 90}, {80, 20, 30, 90}},
+	}
+	fidelity := [2][3][4]float32{
+		{{22, 100, 32, 76}, {40, 100, 65, 22}, {22, 100, 32, 76}},
+		{{80, 20, 32, 76}, {80, 20, 30, 74}, {80, 20, 32, 76}},
+	}
+	for v, want := range map[Variants][2][3][4]float32{
+		TonalSpot:  std,
+		Vibrant:    std,
+		Expressive: std,
+		Fidelity:   fidelity,
+		Content:    fidelity,
+		Monochrome: {
+			{{0, 90, 25, 100}, {40, 100, 85, 10}, {25, 90, 49, 100}},
+			{{100, 10, 85, 0}, {80, 10, 30, 90}, {90, 10, 60, 0}},
+		},
+		Neutral:    std,
+		Rainbow:    std,
+		FruitSalad: std,
+	} {
+		p := NewPalette(KeyFromPrimaryVariant(blue, v))
+		for i, sc := range []Scheme{NewLightScheme(p), NewDarkScheme(p)} {
+			for j, a := range []Accent{sc.Primary, sc.Secondary, sc.Tertiary} {
+				for k, c := range []color.RGBA{a.Base, a.On, a.Container, a.OnContainer} {
+					if have := hct.FromColor(c).Tone; mat32.Abs(have-want[i][j][k]) > 1 {
+						t.Errorf("%v: expected tone %g for color %d of accent %d in scheme %d but got %g", v, want[i][j][k], k, j, i, have)
+					}
+				}
+			}
+		}
+		if v == Monochrome {
+			sc := NewLightScheme(p)
+			for i, want := range []float32{40, 30, 100, 90, 80, 70, 10, 25} {
+				c := []color.RGBA{sc.PrimaryFixed, sc.PrimaryFixedDim, sc.OnPrimaryFixed, sc.OnPrimaryFixedVariant,
+					sc.SecondaryFixed, sc.SecondaryFixedDim, sc.OnSecondaryFixed, sc.OnSecondaryFixedVariant}[i]
+				if have := hct.FromColor(c).Tone; mat32.Abs(have-want) > 1 {
+					t.Errorf("%v: expected tone %g for fixed color %d but got %g", v, want, i, have)
+				}
+			}
+		}
+		if v == Fidelity || v == Content {
+			// the primary container is the source color
+			h, src := hct.FromColor(NewLightScheme(p).Primary.Container), hct.FromColor(blue)
+			if mat32.Abs(hct.MinHueDistance(h.Hue, src.Hue)) > 2 || mat32.Abs(h.Chroma-src.Chroma) > 2 || mat32.Abs(h.Tone-src.Tone) > 1 {
+				t.Errorf("%v: expected primary container %v but got %v", v, src, h)
+			}
+		}
+	}
+
+	src := hct.FromColor(blue)
+	// expected hue offset from the source color and chroma of the primary,
+	// secondary, tertiary, neutral, and neutral variant key colors for each
+	// variant; a negative chroma means the highest available chroma, and a
+	// hue offset of 999 means that the hue is not checked
+	for _, tc := range []struct {
+		v      Variants
+		hues   [5]float32
+		chroma [5]float32
+	}{
+		{TonalSpot, [5]float32{0, 0, 60, 0, 0}, [5]float32{36, 16, 24, 6, 8}},
+		{Vibrant, [5]float32{0, 15, 30, 0, 0}, [5]float32{-1, 24, 32, 10, 12}},
+		{Expressive, [5]float32{240, 45, 20, 15, 15}, [5]float32{40, 24, 32, 8, 12}},
+		{Monochrome, [5]float32{}, [5]float32{0, 0, 0, 0, 0}},
+		{Neutral, [5]float32{}, [5]float32{12, 8, 16, 2, 2}},
+		{Rainbow, [5]float32{0, 0, 60, 0, 0}, [5]float32{48, 16, 24, 0, 0}},
+		{FruitSalad, [5]float32{-50, -50, 0, 0, 0}, [5]float32{48, 36, 36, 10, 16}},
+		{Fidelity, [5]float32{0, 0, 999, 0, 0}, [5]float32{src.Chroma, src.Chroma - 32, -1, src.Chroma / 8, src.Chroma/8 + 4}},
+	} {
+		k := KeyFromPrimaryVariant(blue, tc.v)
+		for i, c := range []color.RGBA{k.Primary, k.Secondary, k.Tertiary, k.Neutral, k.NeutralVariant} {
+			h := hct.FromColor(c)
+			if tc.chroma[i] == 0 {
+				if c.R != c.G || c.G != c.B {
+					t.Errorf("%v: expected gray for key color %d but got %v", tc.v, i, c)
+				}
+				continue
+			}
+			if tc.chroma[i] > 0 && mat32.Abs(h.Chroma-tc.chroma[i]) > 1.5 {
+				t.Errorf("%v: expected chroma %g for key color %d but got %g", tc.v, tc.chroma[i], i, h.Chroma)
+			}
+			// the hues of low-chroma colors are imprecise due to rounding
+			if (tc.chroma[i] < 0 || tc.chroma[i] >= 12) && tc.hues[i] != 999 {
+				want := sanitizeDegrees(src.Hue + tc.hues[i])
+				if mat32.Abs(hct.MinHueDistance(h.Hue, want)) > 2 {
+					t.Errorf("%v: expected hue %g for key color %d but got %g", tc.v, want, i, h.Hue)
+				}
+			}
+		}
+	}
+}
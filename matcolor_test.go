@@ -0,0 +1,65 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package colors
+
+import (
+	"image/color"
+	"reflect"
+	"testing"
+
+	"goki.dev/colors/matcolor"
+)
+
+func TestInterpolateScheme(t *testing.T) {
+	light := &Schemes.Light
+	dark := &Schemes.Dark
+	if s := InterpolateScheme(HCT, light, dark, 0); s.Surface != light.Surface || s.Primary.Base != light.Primary.Base {
+		t.Errorf("expected light scheme at t=0")
+	}
+	s := InterpolateScheme(RGB, light, dark, 0.5)
+	if want := BlendRGB(50, light.Surface, dark.Surface); s.Surface != want {
+		t.Errorf("expected surface %v but got %v", want, s.Surface)
+	}
+	if want := BlendRGB(50, light.Primary.OnContainer, dark.Primary.OnContainer); s.Primary.OnContainer != want {
+		t.Errorf("expected primary on container %v but got %v", want, s.Primary.OnContainer)
+	}
+
+	a := matcolor.Scheme{Custom: map[string]matcolor.Accent{"x": {Base: White}, "y": {Base: Red}}}
+	b := matcolor.Scheme{Custom: map[string]matcolor.Accent{"x": {Base: Black}}}
+	s = InterpolateScheme(RGB, &a, &b, 0.25)
+	if len(s.Custom) != 2 || s.Custom["y"].Base != Red || s.Custom["x"].Base != BlendRGB(75, White, Black) {
+		t.Errorf("unexpected custom accents %v", s.Custom)
+	}
+
+	// every color of the scheme must be interpolated
+	v := reflect.ValueOf(&a).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Type() {
+		case reflect.TypeOf(color.RGBA{}):
+			f.Set(reflect.ValueOf(White))
+		case reflect.TypeOf(matcolor.Accent{}):
+			f.Set(reflect.ValueOf(matcolor.Accent{Base: White, On: White, Container: White, OnContainer: White}))
+		}
+	}
+	s = InterpolateScheme(RGB, &a, &matcolor.Scheme{}, 0.5)
+	v = reflect.ValueOf(s)
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch f.Type() {
+		case reflect.TypeOf(color.RGBA{}):
+			if f.Interface() == (color.RGBA{}) {
+				t.Errorf("expected %s to be interpolated", v.Type().Field(i).Name)
+			}
+		case reflect.TypeOf(matcolor.Accent{}):
+			if ac := f.Interface().(matcolor.Accent); ac.Base == (color.RGBA{}) || ac.On == (color.RGBA{}) || ac.Container == (color.RGBA{}) || ac.OnContainer == (color.RGBA{}) {
+				t.Errorf("expected all of the colors of %s to be interpolated", v.Type().Field(i).Name)
+			}
+		case reflect.TypeOf(map[string]matcolor.Accent{}):
+		default:
+			t.Errorf("unexpected field %s of type %v", v.Type().Field(i).Name, f.Type())
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package oklab provides the OKLab perceptual colorspace by Björn Ottosson
+// (https://bottosson.github.io/posts/oklab), and its polar form OKLCH.
+// OKLab is much simpler and faster to compute than CAM16-UCS and HCT,
+// while still being approximately perceptually uniform.
+package oklab
+
+import (
+	"image/color"
+
+	"goki.dev/cam/cie"
+	"goki.dev/mat32/v2"
+)
+
+// OKLab represents a color in the OKLab colorspace, with a perceived
+// lightness L [0..1] and the green-red (A) and blue-yellow (B) opponent
+// axes, which are typically in the range [-0.4..0.4].
+// The alpha channel is not used for OKLab, but it is maintained
+// so it can be used to fully represent an RGBA color value.
+// When converting to RGBA, alpha multiplies the RGB components.
+type OKLab struct {
+
+	// the perceived lightness of the color
+	L float32 `min:"0" max:"1" step:"0.05"`
+
+	// the green-red opponent axis of the color
+	A float32 `min:"-0.4" max:"0.4" step:"0.02"`
+
+	// the blue-yellow opponent axis of the color
+	B float32 `min:"-0.4" max:"0.4" step:"0.02"`
+
+	// the transparency of the color
+	Alpha float32 `min:"0" max:"1" step:"0.05"`
+}
+
+// New returns a new OKLab color with the given lightness and opponent
+// axis values. Alpha is automatically set to 1.
+func New(l, a, b float32) OKLab {
+	return OKLab{l, a, b, 1}
+}
+
+// FromLCH returns a new OKLab color from the given OKLCH lightness [0..1],
+// chroma [0..~0.4], and hue [0..360] values. Alpha is automatically set to 1.
+func FromLCH(l, c, h float32) OKLab {
+	hr := mat32.DegToRad(h)
+	return New(l, c*mat32.Cos(hr), c*mat32.Sin(hr))
+}
+
+// FromColor returns a new OKLab color from the given standard [color.Color].
+func FromColor(c color.Color) OKLab {
+	r, g, b, a := c.RGBA()
+	if a == 0 {
+		return OKLab{}
+	}
+	fa := float32(a) / 65535
+	fr := float32(r) / 65535 / fa
+	fg := float32(g) / 65535 / fa
+	fb := float32(b) / 65535 / fa
+	l, la, lb := SRGBToOKLab(fr, fg, fb)
+	return OKLab{l, la, lb, fa}
+}
+
+// Model is the standard [color.Model] that converts colors to OKLab.
+var Model = color.ModelFunc(model)
+
+func model(c color.Color) color.Color {
+	if o, ok := c.(OKLab); ok {
+		return o
+	}
+	return FromColor(c)
+}
+
+// RGBA implements the [color.Color] interface. Colors that are outside
+// of the sRGB gamut are clipped. It performs the premultiplication of
+// the RGB components by alpha at this point.
+func (o OKLab) RGBA() (r, g, b, a uint32) {
+	fr, fg, fb := OKLabToSRGB(o.L, o.A, o.B)
+	r = uint32(fr*o.Alpha*65535 + 0.5)
+	g = uint32(fg*o.Alpha*65535 + 0.5)
+	b = uint32(fb*o.Alpha*65535 + 0.5)
+	a = uint32(o.Alpha*65535 + 0.5)
+	return
+}
+
+// AsRGBA returns the color as a standard [color.RGBA] type.
+func (o OKLab) AsRGBA() color.RGBA {
+	fr, fg, fb := OKLabToSRGB(o.L, o.A, o.B)
+	return color.RGBA{uint8(fr*o.Alpha*255 + 0.5), uint8(fg*o.Alpha*255 + 0.5), uint8(fb*o.Alpha*255 + 0.5), uint8(o.Alpha*255 + 0.5)}
+}
+
+// LCH returns the OKLCH lightness [0..1], chroma [0..~0.4],
+// and hue [0..360] values of the color.
+func (o OKLab) LCH() (l, c, h float32) {
+	c = mat32.Sqrt(o.A*o.A + o.B*o.B)
+	h = mat32.RadToDeg(mat32.Atan2(o.B, o.A))
+	if h < 0 {
+		h += 360
+	}
+	return o.L, c, h
+}
+
+// InGamut returns whether the color is within the sRGB gamut.
+func (o OKLab) InGamut() bool {
+	rl, gl, bl := OKLabToLinear(o.L, o.A, o.B)
+	const eps = 1e-4
+	return rl >= -eps && rl <= 1+eps && gl >= -eps && gl <= 1+eps && bl >= -eps && bl <= 1+eps
+}
+
+// Distance returns the Euclidean distance between the two given colors,
+// which is the perceptual color difference (ΔE) in OKLab, in which a
+// difference of about 0.02 is barely noticeable.
+func Distance(x, y OKLab) float32 {
+	dl, da, db := x.L-y.L, x.A-y.A, x.B-y.B
+	return mat32.Sqrt(dl*dl + da*da + db*db)
+}
+
+// SRGBToOKLab converts the given sRGB components [0..1]
+// into OKLab lightness and opponent axis values.
+func SRGBToOKLab(r, g, b float32) (l, la, lb float32) {
+	return LinearToOKLab(cie.SRGBToLinear(r, g, b))
+}
+
+// OKLabToSRGB converts the given OKLab lightness and opponent axis values
+// into sRGB components [0..1], clipping colors outside of the sRGB gamut.
+func OKLabToSRGB(l, la, lb float32) (r, g, b float32) {
+	rl, gl, bl := OKLabToLinear(l, la, lb)
+	return cie.SRGBFmLinear(mat32.Clamp(rl, 0, 1), mat32.Clamp(gl, 0, 1), mat32.Clamp(bl, 0, 1))
+}
+
+// LinearToOKLab converts the given linear sRGB components [0..1]
+// into OKLab lightness and opponent axis values.
+func LinearToOKLab(r, g, b float32) (l, la, lb float32) {
+	lc := mat32.Cbrt(0.4122214708*r + 0.5363325363*g + 0.0514459929*b)
+	mc := mat32.Cbrt(0.2119034982*r + 0.6806995451*g + 0.1073969566*b)
+	sc := mat32.Cbrt(0.0883024619*r + 0.2817188376*g + 0.6299787005*b)
+
+	l = 0.2104542553*lc + 0.7936177850*mc - 0.0040720468*sc
+	la = 1.9779984951*lc - 2.4285922050*mc + 0.4505937099*sc
+	lb = 0.0259040371*lc + 0.7827717662*mc - 0.8086757660*sc
+	return
+}
+
+// OKLabToLinear converts the given OKLab lightness and opponent axis values
+// into linear sRGB components, which are outside of [0..1] for colors
+// outside of the sRGB gamut.
+func OKLabToLinear(l, la, lb float32) (r, g, b float32) {
+	lc := l + 0.3963377774*la + 0.2158037573*lb
+	mc := l - 0.1055613458*la - 0.0638541728*lb
+	sc := l - 0.0894841775*la - 1.2914855480*lb
+
+	lc, mc, sc = lc*lc*lc, mc*mc*mc, sc*sc*sc
+
+	r = 4.0767416621*lc - 3.3077115913*mc + 0.2309699292*sc
+	g = -1.2684380046*lc + 2.6097574011*mc - 0.3413193965*sc
+	b = -0.0041960863*lc - 0.7034186147*mc + 1.7076147010*sc
+	return
+}
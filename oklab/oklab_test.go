@@ -0,0 +1,42 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oklab
+
+import (
+	"image/color"
+	"testing"
+
+	"goki.dev/mat32/v2"
+)
+
+func TestOKLab(t *testing.T) {
+	tests := []struct {
+		c       color.RGBA
+		l, a, b float32
+	}{
+		{color.RGBA{255, 255, 255, 255}, 1, 0, 0},
+		{color.RGBA{0, 0, 0, 255}, 0, 0, 0},
+		{color.RGBA{255, 0, 0, 255}, 0.62796, 0.22486, 0.12585},
+		{color.RGBA{0, 0, 255, 255}, 0.45201, -0.03246, -0.31153},
+	}
+	for _, test := range tests {
+		o := FromColor(test.c)
+		if mat32.Abs(o.L-test.l) > 1e-3 || mat32.Abs(o.A-test.a) > 1e-3 || mat32.Abs(o.B-test.b) > 1e-3 {
+			t.Errorf("%v: expected %g %g %g but got %v", test.c, test.l, test.a, test.b, o)
+		}
+		if rt := o.AsRGBA(); rt != test.c {
+			t.Errorf("%v: expected round trip but got %v", test.c, rt)
+		}
+	}
+
+	o := FromLCH(0.7, 0.1, 120)
+	l, c, h := o.LCH()
+	if mat32.Abs(l-0.7) > 1e-5 || mat32.Abs(c-0.1) > 1e-5 || mat32.Abs(h-120) > 1e-3 {
+		t.Errorf("expected LCH round trip but got %g %g %g", l, c, h)
+	}
+	if !o.InGamut() || FromLCH(0.9, 0.4, 260).InGamut() {
+		t.Errorf("unexpected gamut results")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantize
+
+import (
+	"image/color"
+	"math/rand"
+
+	"goki.dev/colors/oklab"
+)
+
+// kmeansIterations is the maximum number of iterations of [KMeans].
+const kmeansIterations = 20
+
+// KMeans quantizes the given pixels into at most the given number of colors
+// using k-means clustering in the OKLab colorspace (see [oklab.OKLab]), with
+// each unique color weighted by the number of pixels that have it. The initial
+// clusters are chosen with the k-means++ algorithm, starting from the most
+// common color, which spreads them across the colors of the image. It is slower
+// than the other quantizers, but as OKLab is perceptually uniform, the colors
+// are typically the most accurate. The results are deterministic for the same
+// pixels. Pixels that are not fully opaque are ignored.
+func KMeans(pixels []color.RGBA, maxColors int) *Palette {
+	cs, ns := histogram(pixels)
+	counts := map[color.RGBA]int{}
+	nc := min(maxColors, len(cs))
+	if nc <= 0 {
+		return newPalette(counts)
+	}
+	points := make([]oklab.OKLab, len(cs))
+	first := 0
+	for i, c := range cs {
+		points[i] = oklab.FromColor(c)
+		if ns[i] > ns[first] {
+			first = i
+		}
+	}
+
+	// k-means++ initialization: each cluster is a random color chosen with a
+	// probability proportional to its weighted squared distance from the
+	// closest existing cluster
+	clusters := make([]oklab.OKLab, 1, nc)
+	clusters[0] = points[first]
+	rnd := rand.New(rand.NewSource(0x42688))
+	dists := make([]float64, len(points))
+	for i, p := range points {
+		d := float64(oklab.Distance(p, clusters[0]))
+		dists[i] = d * d * float64(ns[i])
+	}
+	for len(clusters) < nc {
+		total := 0.0
+		for _, d := range dists {
+			total += d
+		}
+		if total == 0 {
+			break // all of the colors are already clusters
+		}
+		r := rnd.Float64() * total
+		next := len(points) - 1
+		for i, d := range dists {
+			if r -= d; r < 0 && d > 0 {
+				next = i
+				break
+			}
+		}
+		c := points[next]
+		clusters = append(clusters, c)
+		for i, p := range points {
+			d := float64(oklab.Distance(p, c))
+			dists[i] = min(dists[i], d*d*float64(ns[i]))
+		}
+	}
+	nc = len(clusters)
+
+	assign := make([]int, len(points))
+	for i := range assign {
+		assign[i] = -1
+	}
+	populations := make([]int, nc)
+	for iter := 0; iter < kmeansIterations; iter++ {
+		moved := 0
+		for i, p := range points {
+			best, bestDist := 0, oklab.Distance(p, clusters[0])
+			for j := 1; j < nc; j++ {
+				if d := oklab.Distance(p, clusters[j]); d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+			if assign[i] != best {
+				assign[i] = best
+				moved++
+			}
+		}
+		if moved == 0 {
+			break
+		}
+
+		sums := make([][3]float64, nc)
+		clear(populations)
+		for i, p := range points {
+			c, w := assign[i], float64(ns[i])
+			populations[c] += ns[i]
+			sums[c][0] += float64(p.L) * w
+			sums[c][1] += float64(p.A) * w
+			sums[c][2] += float64(p.B) * w
+		}
+		for i, s := range sums {
+			if populations[i] == 0 {
+				continue // the cluster stays where it is
+			}
+			n := float64(populations[i])
+			clusters[i] = oklab.New(float32(s[0]/n), float32(s[1]/n), float32(s[2]/n))
+		}
+	}
+
+	for i, c := range clusters {
+		if populations[i] == 0 {
+			continue
+		}
+		// clusters that round to the same color are merged
+		counts[c.AsRGBA()] += populations[i]
+	}
+	return newPalette(counts)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantize
+
+import (
+	"image/color"
+	"math"
+	"sort"
+)
+
+// MedianCut quantizes the given pixels into at most the given number of
+// colors using Heckbert's median cut algorithm (SIGGRAPH 1982), which
+// repeatedly splits the box of the RGB color cube with the largest range
+// along its longest side at the median pixel, and uses the mean color of
+// the pixels in each box. It is simple and fast, but it is typically less
+// accurate than [Wu] and [KMeans]. Pixels that are not fully opaque are ignored.
+func MedianCut(pixels []color.RGBA, maxColors int) *Palette {
+	cs, ns := histogram(pixels)
+	counts := map[color.RGBA]int{}
+	if maxColors <= 0 || len(cs) == 0 {
+		return newPalette(counts)
+	}
+	entries := make([]mcEntry, len(cs))
+	for i, c := range cs {
+		entries[i] = mcEntry{c, ns[i]}
+	}
+	boxes := []*mcBox{newMCBox(entries)}
+	for len(boxes) < maxColors {
+		var split *mcBox
+		bi := 0
+		for i, b := range boxes {
+			if b.rng > 0 && (split == nil || b.rng > split.rng) {
+				split, bi = b, i
+			}
+		}
+		if split == nil {
+			break // all of the boxes have a single color
+		}
+		one, two := split.split()
+		boxes[bi] = one
+		boxes = append(boxes, two)
+	}
+	for _, b := range boxes {
+		counts[b.mean()] += b.total
+	}
+	return newPalette(counts)
+}
+
+// mcEntry is a unique color and the number of pixels with it.
+type mcEntry struct {
+	color color.RGBA
+	count int
+}
+
+// mcBox is a box of the RGB color cube used in [MedianCut].
+type mcBox struct {
+
+	// the colors in the box
+	entries []mcEntry
+
+	// the total number of pixels in the box
+	total int
+
+	// the channel along which the box is longest (0-2 for RGB)
+	channel int
+
+	// the range of the box along that channel
+	rng int
+}
+
+// newMCBox returns a new box containing the given entries.
+func newMCBox(entries []mcEntry) *mcBox {
+	b := &mcBox{entries: entries}
+	lo := [3]uint8{255, 255, 255}
+	hi := [3]uint8{}
+	for _, e := range entries {
+		b.total += e.count
+		for ch, v := range [3]uint8{e.color.R, e.color.G, e.color.B} {
+			lo[ch] = min(lo[ch], v)
+			hi[ch] = max(hi[ch], v)
+		}
+	}
+	for ch := range lo {
+		if r := int(hi[ch]) - int(lo[ch]); r > b.rng {
+			b.rng = r
+			b.channel = ch
+		}
+	}
+	return b
+}
+
+// split splits the box along its longest channel at the median pixel.
+// It must only be called on boxes with a non-zero range.
+func (b *mcBox) split() (*mcBox, *mcBox) {
+	sort.SliceStable(b.entries, func(i, j int) bool {
+		return mcChannel(b.entries[i].color, b.channel) < mcChannel(b.entries[j].color, b.channel)
+	})
+	// split after the entry that contains the median pixel,
+	// ensuring that both boxes contain at least one color
+	cum := 0
+	at := 1
+	for i, e := range b.entries[:len(b.entries)-1] {
+		cum += e.count
+		at = i + 1
+		if 2*cum >= b.total {
+			break
+		}
+	}
+	return newMCBox(b.entries[:at]), newMCBox(b.entries[at:])
+}
+
+// mean returns the mean color of the pixels in the box.
+func (b *mcBox) mean() color.RGBA {
+	var r, g, bl float64
+	for _, e := range b.entries {
+		n := float64(e.count)
+		r += float64(e.color.R) * n
+		g += float64(e.color.G) * n
+		bl += float64(e.color.B) * n
+	}
+	n := float64(b.total)
+	return color.RGBA{uint8(math.Round(r / n)), uint8(math.Round(g / n)), uint8(math.Round(bl / n)), 255}
+}
+
+// mcChannel returns the value of the given channel (0-2 for RGB) of the color.
+func mcChannel(c color.RGBA, ch int) uint8 {
+	switch ch {
+	case 0:
+		return c.R
+	case 1:
+		return c.G
+	}
+	return c.B
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantize
+
+import (
+	"image/color"
+	"math"
+)
+
+// octreeDepth is the depth of the leaves of the octree used in [Octree],
+// at which each leaf is a single 8-bit color.
+const octreeDepth = 8
+
+// Octree quantizes the given pixels into at most the given number of colors
+// using the octree algorithm of Gervautz and Purgathofer (1988), which puts
+// the colors into a tree that divides the RGB color cube into eight octants
+// at each level, and then repeatedly merges the least common leaves at the
+// deepest level into their parent until there are few enough leaves.
+// It uses little memory and works well for images with many unique colors,
+// but it is typically less accurate than [Wu] and [KMeans].
+// Pixels that are not fully opaque are ignored.
+func Octree(pixels []color.RGBA, maxColors int) *Palette {
+	cs, ns := histogram(pixels)
+	counts := map[color.RGBA]int{}
+	if maxColors <= 0 || len(cs) == 0 {
+		return newPalette(counts)
+	}
+	t := &octree{root: &octNode{}}
+	for i, c := range cs {
+		t.add(c, ns[i])
+	}
+	for t.leaves > maxColors {
+		t.reduce()
+	}
+	t.root.colors(counts)
+	return newPalette(counts)
+}
+
+// octree is the tree of colors used in [Octree].
+type octree struct {
+	root *octNode
+
+	// the number of leaves in the tree
+	leaves int
+
+	// the nodes with children at each level, in the order they were added
+	levels [octreeDepth][]*octNode
+}
+
+// octNode is a node of an [octree], which contains the total
+// number of pixels and sums of the channels of its subtree.
+type octNode struct {
+	children   [8]*octNode
+	count      int
+	r, g, b    int
+	isLeaf     bool
+	isReducing bool
+}
+
+// add adds the given color with the given number of pixels to the tree.
+func (t *octree) add(c color.RGBA, n int) {
+	nd := t.root
+	for level := 0; ; level++ {
+		nd.count += n
+		nd.r += int(c.R) * n
+		nd.g += int(c.G) * n
+		nd.b += int(c.B) * n
+		if nd.isLeaf {
+			return
+		}
+		if level == octreeDepth {
+			nd.isLeaf = true
+			t.leaves++
+			return
+		}
+		if !nd.isReducing {
+			nd.isReducing = true
+			t.levels[level] = append(t.levels[level], nd)
+		}
+		shift := octreeDepth - 1 - level
+		i := int(c.R>>shift&1)<<2 | int(c.G>>shift&1)<<1 | int(c.B>>shift&1)
+		if nd.children[i] == nil {
+			nd.children[i] = &octNode{}
+		}
+		nd = nd.children[i]
+	}
+}
+
+// reduce merges the children of the node with the fewest pixels at the
+// deepest level that has nodes with children into that node.
+func (t *octree) reduce() {
+	level := octreeDepth - 1
+	for len(t.levels[level]) == 0 {
+		level--
+	}
+	nodes := t.levels[level]
+	mi := 0
+	for i, nd := range nodes {
+		if nd.count < nodes[mi].count {
+			mi = i
+		}
+	}
+	nd := nodes[mi]
+	t.levels[level] = append(nodes[:mi], nodes[mi+1:]...)
+	for i, c := range nd.children {
+		if c != nil {
+			t.leaves--
+			nd.children[i] = nil
+		}
+	}
+	nd.isLeaf = true
+	nd.isReducing = false
+	t.leaves++
+}
+
+// colors adds the mean color of each leaf in the subtree of
+// the node and its number of pixels to the given map.
+func (nd *octNode) colors(counts map[color.RGBA]int) {
+	if nd.isLeaf {
+		n := float64(nd.count)
+		c := color.RGBA{
+			uint8(math.Round(float64(nd.r) / n)),
+			uint8(math.Round(float64(nd.g) / n)),
+			uint8(math.Round(float64(nd.b) / n)),
+			255,
+		}
+		// leaves that round to the same color are merged
+		counts[c] += nd.count
+		return
+	}
+	for _, c := range nd.children {
+		if c != nil {
+			c.colors(counts)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package quantize provides color quantization algorithms, which reduce
+// the colors of an image to a small palette of representative colors,
+// as used for extracting the colors of an image for a color scheme
+// and for exporting images with a limited number of colors, such as
+// GIF and 8-bit PNG images. The [Wu], [WSMeans], and [Celebi] algorithms
+// are based on those of the Material color utilities
+// (https://github.com/material-foundation/material-color-utilities).
+package quantize
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"slices"
+	"sort"
+
+	"goki.dev/cam/cie"
+	"goki.dev/mat32/v2"
+)
+
+// Palette is the result of color quantization, which is a palette
+// of colors with the number of pixels that each color represents.
+type Palette struct {
+
+	// the colors of the palette, which are all [color.RGBA] values,
+	// sorted from the most to the least common
+	Colors color.Palette
+
+	// the number of pixels represented by each color
+	Counts []int
+}
+
+// Func is a color quantization function, which quantizes the given pixels
+// into a palette of at most the given number of colors, such as [Wu],
+// [MedianCut], [Octree], [KMeans], and [Celebi].
+type Func func(pixels []color.RGBA, maxColors int) *Palette
+
+// Image quantizes the opaque pixels of the given image (see [Pixels]) into
+// a palette of at most the given number of colors using the given function.
+func Image(img image.Image, maxColors int, fun Func) *Palette {
+	return fun(Pixels(img, 0), maxColors)
+}
+
+// newPalette returns a new [Palette] with the given colors and
+// counts, sorted from the most to the least common color.
+func newPalette(counts map[color.RGBA]int) *Palette {
+	cs := make([]color.RGBA, 0, len(counts))
+	for c := range counts {
+		cs = append(cs, c)
+	}
+	sort.Slice(cs, func(i, j int) bool {
+		a, b := cs[i], cs[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		// break ties deterministically, as map iteration order is random
+		if a.R != b.R {
+			return a.R < b.R
+		}
+		if a.G != b.G {
+			return a.G < b.G
+		}
+		return a.B < b.B
+	})
+	p := &Palette{Colors: make(color.Palette, len(cs)), Counts: make([]int, len(cs))}
+	for i, c := range cs {
+		p.Colors[i] = c
+		p.Counts[i] = counts[c]
+	}
+	return p
+}
+
+// Map returns a map from each color of the palette to its count.
+func (p *Palette) Map() map[color.RGBA]int {
+	m := make(map[color.RGBA]int, len(p.Colors))
+	for i, c := range p.Colors {
+		m[c.(color.RGBA)] = p.Counts[i]
+	}
+	return m
+}
+
+// Paletted returns a new paletted image with the palette colors that
+// is the given image drawn with the nearest palette color for each
+// pixel, which can be encoded as a GIF or 8-bit PNG image. If dither
+// is true, Floyd-Steinberg error diffusion is used, which reduces
+// banding in images with gradients. The palette must have at most
+// 256 colors for the image to be encoded. If the image has pixels that
+// are not fully opaque, which are ignored by quantization, a transparent
+// color is added to the palette of the new image for them, replacing the
+// least common color if the palette already has 256 colors, so that the
+// transparency is kept. The transparent color is also used if the palette
+// is empty, such as for a fully transparent image.
+func (p *Palette) Paletted(img image.Image, dither bool) *image.Paletted {
+	b := img.Bounds()
+	pal := slices.Clone(p.Colors)
+	if len(pal) == 0 || !isOpaque(img) {
+		if len(pal) >= 256 {
+			pal = pal[:255]
+		}
+		pal = append(pal, color.RGBA{})
+	}
+	pi := image.NewPaletted(b, pal)
+	if dither {
+		draw.FloydSteinberg.Draw(pi, b, img, b.Min)
+	} else {
+		draw.Draw(pi, b, img, b.Min, draw.Src)
+	}
+	return pi
+}
+
+// isOpaque returns whether all of the pixels of the given image are fully opaque.
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, _, _, a := img.At(x, y).RGBA(); a != 0xffff {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Pixels returns the opaque pixels of the given image, skipping pixels that
+// are not fully opaque, as color quantization is only meaningful for opaque
+// colors. If max is greater than 0, the image is sampled at evenly spaced
+// pixels such that about max pixels are sampled, which is much faster for
+// large images and typically does not change the results of quantization
+// significantly.
+func Pixels(img image.Image, max int) []color.RGBA {
+	b := img.Bounds()
+	step := 1
+	if max > 0 {
+		for (b.Dx()/step)*(b.Dy()/step) > max {
+			step++
+		}
+	}
+	px := make([]color.RGBA, 0, (b.Dx()/step+1)*(b.Dy()/step+1))
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			if c.A == 255 {
+				px = append(px, c)
+			}
+		}
+	}
+	return px
+}
+
+// histogram returns the unique opaque colors of the given pixels in the
+// order in which they first occur, and the number of pixels with each color.
+func histogram(pixels []color.RGBA) ([]color.RGBA, []int) {
+	idx := map[color.RGBA]int{}
+	var cs []color.RGBA
+	var ns []int
+	for _, p := range pixels {
+		if p.A != 255 {
+			continue
+		}
+		i, ok := idx[p]
+		if !ok {
+			i = len(cs)
+			idx[p] = i
+			cs = append(cs, p)
+			ns = append(ns, 0)
+		}
+		ns[i]++
+	}
+	return cs, ns
+}
+
+// Celebi quantizes the given pixels into at most the given number of colors
+// using Wu's algorithm (see [Wu]) to find the starting clusters for the
+// weighted square means algorithm (see [WSMeans]), as described by Celebi
+// (https://doi.org/10.1016/j.imavis.2010.10.001). This combination
+// is both fast and accurate, and it is the quantizer used by Material for
+// extracting the colors of an image.
+func Celebi(pixels []color.RGBA, maxColors int) *Palette {
+	starting, _ := wuClusters(pixels, maxColors)
+	return WSMeans(pixels, starting, maxColors)
+}
+
+// lab is a color in the CIE L*a*b* colorspace, in which the squared
+// euclidean distance between colors is used for quantization.
+type lab [3]float32
+
+// toLab returns the given color in the CIE L*a*b* colorspace.
+func toLab(c color.RGBA) lab {
+	x, y, z := cie.SRGBToXYZ(float32(c.R)/255, float32(c.G)/255, float32(c.B)/255)
+	l, a, b := cie.XYZToLAB(x, y, z)
+	return lab{l, a, b}
+}
+
+// rgba returns the opaque sRGB color for the color.
+func (l lab) rgba() color.RGBA {
+	x, y, z := cie.LABToXYZ(l[0], l[1], l[2])
+	r, g, b := cie.SRGBFmLinear(cie.XYZToSRGBLin(x, y, z))
+	return color.RGBA{channel(r), channel(g), channel(b), 255}
+}
+
+// distance returns the squared euclidean distance between the colors.
+func (l lab) distance(o lab) float32 {
+	dl, da, db := l[0]-o[0], l[1]-o[1], l[2]-o[2]
+	return dl*dl + da*da + db*db
+}
+
+// channel returns the given 0-1 channel value as a clamped 8-bit value.
+func channel(v float32) uint8 {
+	return uint8(mat32.Round(mat32.Clamp(v, 0, 1) * 255))
+}
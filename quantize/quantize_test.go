@@ -0,0 +1,211 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package quantize
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+)
+
+var (
+	red   = color.RGBA{255, 0, 0, 255}
+	green = color.RGBA{0, 255, 0, 255}
+	blue  = color.RGBA{0, 0, 255, 255}
+)
+
+// sortedColors returns the sorted colors of the given palette.
+func sortedColors(p *Palette) []color.RGBA {
+	var cs []color.RGBA
+	for _, c := range p.Colors {
+		cs = append(cs, c.(color.RGBA))
+	}
+	sortColors(cs)
+	return cs
+}
+
+// sortColors sorts the given colors by their channel values.
+func sortColors(cs []color.RGBA) {
+	slices.SortFunc(cs, func(a, b color.RGBA) int {
+		return int(a.R)<<16 + int(a.G)<<8 + int(a.B) - (int(b.R)<<16 + int(b.G)<<8 + int(b.B))
+	})
+}
+
+// the cases are from the tests of the Material color utilities
+func TestWu(t *testing.T) {
+	for _, tc := range []struct {
+		pixels []color.RGBA
+		want   []color.RGBA
+	}{
+		{[]color.RGBA{{0x14, 0x12, 0x16, 255}}, []color.RGBA{{0x14, 0x12, 0x16, 255}}},
+		{[]color.RGBA{red, red, red, red, red}, []color.RGBA{red}},
+		{[]color.RGBA{red, green, blue}, []color.RGBA{blue, green, red}},
+		{[]color.RGBA{red, red, green, green, green, blue, blue, blue, blue}, []color.RGBA{blue, green, red}},
+		{[]color.RGBA{{255, 0, 0, 128}}, nil},
+	} {
+		have := sortedColors(Wu(tc.pixels, 128))
+		if !slices.Equal(have, tc.want) {
+			t.Errorf("expected %v but got %v", tc.want, have)
+		}
+	}
+	// three colors for two boxes
+	if have := Wu([]color.RGBA{red, green, blue}, 2); len(have.Colors) != 2 {
+		t.Errorf("expected 2 colors but got %v", have)
+	}
+	// the starting clusters of Celebi are in the order of the boxes
+	cs, ns := wuClusters([]color.RGBA{red, red, green, green, green, blue, blue, blue, blue}, 128)
+	if !slices.Equal(cs, []color.RGBA{green, blue, red}) || !slices.Equal(ns, []int{3, 4, 2}) {
+		t.Errorf("expected green, blue, and red boxes but got %v %v", cs, ns)
+	}
+}
+
+func TestCelebi(t *testing.T) {
+	px := []color.RGBA{red, red, green, green, green, blue, blue, blue, blue}
+	res := Celebi(px, 128)
+	if want, have := []color.RGBA{blue, green, red}, sortedColors(res); !slices.Equal(want, have) {
+		t.Fatalf("expected %v but got %v", want, have)
+	}
+	if m := res.Map(); m[red] != 2 || m[green] != 3 || m[blue] != 4 {
+		t.Errorf("expected counts of 2, 3, and 4 but got %v", m)
+	}
+
+	// similar colors are merged into one
+	px = []color.RGBA{{200, 10, 10, 255}, {202, 12, 10, 255}, {198, 10, 12, 255}, {10, 10, 200, 255}}
+	res = Celebi(px, 2)
+	if len(res.Colors) != 2 {
+		t.Fatalf("expected 2 colors but got %v", res.Colors)
+	}
+	for c, n := range res.Map() {
+		if c.R > 150 && n != 3 {
+			t.Errorf("expected 3 red pixels but got %d for %v", n, c)
+		}
+	}
+}
+
+func TestWSMeans(t *testing.T) {
+	px := []color.RGBA{red, red, green, green, green, blue, blue, blue, blue}
+	res := WSMeans(px, nil, 3)
+	if want, have := []color.RGBA{blue, green, red}, sortedColors(res); !slices.Equal(want, have) {
+		t.Errorf("expected %v but got %v", want, have)
+	}
+	if res := WSMeans(px, nil, 8); len(res.Colors) != 3 {
+		t.Errorf("expected 3 colors for 3 unique colors but got %v", res.Colors)
+	}
+}
+
+func TestPixels(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	img.SetRGBA(0, 0, color.RGBA{})
+	if n := len(Pixels(img, 0)); n != 100*100-1 {
+		t.Errorf("expected %d opaque pixels but got %d", 100*100-1, n)
+	}
+	if n := len(Pixels(img, 1000)); n > 1000 || n < 500 {
+		t.Errorf("expected about 1000 sampled pixels but got %d", n)
+	}
+}
+
+// quantizers are all of the quantizers that return a palette
+var quantizers = map[string]Func{
+	"Wu":        Wu,
+	"MedianCut": MedianCut,
+	"Octree":    Octree,
+	"KMeans":    KMeans,
+	"Celebi":    Celebi,
+}
+
+func TestQuantizers(t *testing.T) {
+	px := []color.RGBA{red, red, green, green, green, blue, blue, blue, blue, {255, 0, 0, 128}}
+	for nm, q := range quantizers {
+		res := q(px, 128)
+		if want, have := []color.RGBA{blue, green, red}, sortedColors(res); !slices.Equal(want, have) {
+			t.Errorf("%s: expected %v but got %v", nm, want, have)
+		}
+		// sorted from the most to the least common
+		if want := []int{4, 3, 2}; !slices.Equal(res.Counts, want) {
+			t.Errorf("%s: expected counts %v but got %v", nm, want, res.Counts)
+		}
+		if res := q(nil, 8); len(res.Colors) != 0 {
+			t.Errorf("%s: expected no colors for no pixels but got %v", nm, res.Colors)
+		}
+	}
+}
+
+func TestQuantizersImage(t *testing.T) {
+	// a gradient from red to blue on top of a solid green half
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			if y >= 32 {
+				img.SetRGBA(x, y, color.RGBA{0, 160, 0, 255})
+				continue
+			}
+			v := uint8(x * 4)
+			img.SetRGBA(x, y, color.RGBA{255 - v, 0, v, 255})
+		}
+	}
+	for nm, q := range quantizers {
+		res := Image(img, 8, q)
+		if len(res.Colors) > 8 || len(res.Colors) < 4 {
+			t.Errorf("%s: expected 4 to 8 colors but got %v", nm, res.Colors)
+		}
+		total := 0
+		for _, n := range res.Counts {
+			total += n
+		}
+		if total != 64*64 {
+			t.Errorf("%s: expected counts to add up to %d but got %d", nm, 64*64, total)
+		}
+		// the solid green half is the most common color
+		if c := res.Colors[0].(color.RGBA); c.G < 140 || c.R > 20 || c.B > 20 || res.Counts[0] < 32*64 {
+			t.Errorf("%s: expected green to be the most common color but got %v (%d)", nm, c, res.Counts[0])
+		}
+		pi := res.Paletted(img, true)
+		if len(pi.Palette) != len(res.Colors) || pi.ColorIndexAt(0, 63) != 0 {
+			t.Errorf("%s: expected paletted image with green at index 0 but got %d", nm, pi.ColorIndexAt(0, 63))
+		}
+	}
+	if res := Image(img, 1, KMeans); len(res.Colors) != 1 {
+		t.Errorf("expected 1 color but got %v", res.Colors)
+	}
+}
+
+func TestPalettedTransparent(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for _, dither := range []bool{false, true} {
+		pi := Image(img, 8, KMeans).Paletted(img, dither)
+		if len(pi.Palette) != 1 || pi.Palette[0] != (color.RGBA{}) || pi.ColorIndexAt(3, 3) != 0 {
+			t.Errorf("expected fully transparent image to have only a transparent color but got %v", pi.Palette)
+		}
+	}
+
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 8; y++ {
+			img.SetRGBA(x, y, red)
+		}
+	}
+	res := Image(img, 8, Wu)
+	pi := res.Paletted(img, false)
+	if len(pi.Palette) != 2 || pi.At(0, 0) != red || pi.At(7, 7) != (color.RGBA{}) {
+		t.Errorf("expected red and transparent but got %v", pi.Palette)
+	}
+
+	// the least common color is replaced in a full palette
+	full := &Palette{}
+	for i := 0; i < 256; i++ {
+		full.Colors = append(full.Colors, color.RGBA{uint8(i), 0, 0, 255})
+		full.Counts = append(full.Counts, 256-i)
+	}
+	pi = full.Paletted(img, false)
+	if len(pi.Palette) != 256 || pi.Palette[255] != (color.RGBA{}) || pi.At(7, 7) != (color.RGBA{}) {
+		t.Errorf("expected 256 colors with a transparent color at the end but got %d", len(pi.Palette))
+	}
+	if len(full.Colors) != 256 {
+		t.Errorf("expected palette to be unchanged")
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/quantize/quantizer_wsmeans.dart
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quantize
+
+import (
+	"image/color"
+	"math/rand"
+)
+
+// wsmeansIterations is the maximum number of iterations of [WSMeans].
+const wsmeansIterations = 10
+
+// WSMeans quantizes the given pixels into at most the given number of colors
+// using the weighted square means algorithm, which is k-means clustering in
+// the CIE L*a*b* colorspace, with each unique color weighted by the number of
+// pixels that have it. The given starting clusters are used as the initial
+// cluster centers, which are typically the result of [Wu] (see [Celebi]), and
+// random pixels are used if there are none. It uses the triangle inequality
+// to skip clusters that can not be closer than the current one, as described
+// by Celebi (https://doi.org/10.1016/j.imavis.2010.10.001).
+// Pixels that are not fully opaque are ignored.
+func WSMeans(pixels []color.RGBA, starting []color.RGBA, maxColors int) *Palette {
+	counts := map[color.RGBA]int{}
+	var uniq []color.RGBA
+	for _, p := range pixels {
+		if p.A != 255 {
+			continue
+		}
+		if counts[p] == 0 {
+			uniq = append(uniq, p)
+		}
+		counts[p]++
+	}
+	npts := len(uniq)
+	points := make([]lab, npts)
+	weights := make([]int, npts)
+	for i, p := range uniq {
+		points[i] = toLab(p)
+		weights[i] = counts[p]
+	}
+
+	nc := min(maxColors, npts)
+	clusters := make([]lab, 0, max(nc, len(starting)))
+	for _, c := range starting {
+		clusters = append(clusters, toLab(c))
+	}
+	// the random generator has a fixed seed so that the results are deterministic;
+	// they do not match those of Material, which uses a different generator
+	rnd := rand.New(rand.NewSource(0x42688))
+	if len(starting) == 0 && nc > 0 {
+		for _, i := range rnd.Perm(npts)[:nc] {
+			clusters = append(clusters, points[i])
+		}
+	}
+	nc = len(clusters)
+	if nc == 0 {
+		return newPalette(map[color.RGBA]int{})
+	}
+
+	assign := make([]int, npts)
+	for i := range assign {
+		assign[i] = rnd.Intn(nc)
+	}
+	dists := make([][]float32, nc)
+	for i := range dists {
+		dists[i] = make([]float32, nc)
+	}
+	populations := make([]int, nc)
+	for iter := 0; iter < wsmeansIterations; iter++ {
+		for i := 0; i < nc; i++ {
+			for j := i + 1; j < nc; j++ {
+				d := clusters[i].distance(clusters[j])
+				dists[i][j], dists[j][i] = d, d
+			}
+		}
+
+		moved := 0
+		for i, p := range points {
+			prev := assign[i]
+			prevDist := p.distance(clusters[prev])
+			minDist := prevDist
+			next := -1
+			for j := range clusters {
+				// the squared distances satisfy d(p, c_j) >= d(p, c_prev) if
+				// d(c_prev, c_j) >= 4 d(p, c_prev) by the triangle inequality
+				if dists[prev][j] >= 4*prevDist {
+					continue
+				}
+				if d := p.distance(clusters[j]); d < minDist {
+					minDist = d
+					next = j
+				}
+			}
+			if next != -1 {
+				moved++
+				assign[i] = next
+			}
+		}
+		if moved == 0 && iter > 0 {
+			break
+		}
+
+		sums := make([][3]float64, nc)
+		clear(populations)
+		for i, p := range points {
+			c, w := assign[i], weights[i]
+			populations[c] += w
+			for k := range p {
+				sums[c][k] += float64(p[k]) * float64(w)
+			}
+		}
+		for i := range clusters {
+			n := float64(populations[i])
+			if n == 0 {
+				clusters[i] = lab{}
+				continue
+			}
+			clusters[i] = lab{float32(sums[i][0] / n), float32(sums[i][1] / n), float32(sums[i][2] / n)}
+		}
+	}
+
+	res := map[color.RGBA]int{}
+	for i, c := range clusters {
+		if populations[i] == 0 {
+			continue
+		}
+		// clusters that round to the same color are merged
+		res[c.rgba()] += populations[i]
+	}
+	return newPalette(res)
+}
@@ -0,0 +1,328 @@
+// Copyright (c) 2023, The Goki Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Based on https://github.com/material-foundation/material-color-utilities/blob/main/dart/lib/quantize/quantizer_wu.dart
+// Copyright 2021 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quantize
+
+import (
+	"image/color"
+	"math"
+)
+
+const (
+	// wuIndexBits is the number of bits of each channel used for the histogram
+	wuIndexBits = 5
+
+	// wuSide is the number of histogram cells along each channel, plus one
+	// for the zero cells that simplify the computation of the moments
+	wuSide = 1<<wuIndexBits + 1
+
+	// wuTotal is the total number of histogram cells
+	wuTotal = wuSide * wuSide * wuSide
+)
+
+// Wu quantizes the given pixels into at most the given number of colors
+// using Wu's algorithm (Graphics Gems II, 1991), which recursively splits
+// boxes of the RGB color cube at the point that minimizes the variance of
+// the colors in them. It is fast, and the resulting colors are typically used
+// as the starting clusters for a more accurate quantizer (see [Celebi]).
+// Pixels that are not fully opaque are ignored.
+func Wu(pixels []color.RGBA, maxColors int) *Palette {
+	counts := map[color.RGBA]int{}
+	cs, ns := wuClusters(pixels, maxColors)
+	for i, c := range cs {
+		// boxes with the same mean color are merged
+		counts[c] += ns[i]
+	}
+	return newPalette(counts)
+}
+
+// wuClusters returns the mean colors of the boxes of [Wu] and the number of
+// pixels in each of them, in the order in which the boxes are created and
+// without merging boxes with the same mean color, which are used as the
+// starting clusters of [Celebi] in the same order as in Material.
+func wuClusters(pixels []color.RGBA, maxColors int) ([]color.RGBA, []int) {
+	if maxColors <= 0 {
+		return nil, nil
+	}
+	w := &wu{}
+	w.histogram(pixels)
+	w.computeMoments()
+	boxes := w.createBoxes(maxColors)
+	var cs []color.RGBA
+	var ns []int
+	for _, b := range boxes {
+		weight := w.volume(b, w.weights[:])
+		if weight <= 0 {
+			continue
+		}
+		cs = append(cs, color.RGBA{
+			uint8(math.Round(w.volume(b, w.momentsR[:]) / weight)),
+			uint8(math.Round(w.volume(b, w.momentsG[:]) / weight)),
+			uint8(math.Round(w.volume(b, w.momentsB[:]) / weight)),
+			255,
+		})
+		ns = append(ns, int(weight))
+	}
+	return cs, ns
+}
+
+// wu contains the histogram and cumulative moments of the colors
+// used in Wu's algorithm.
+type wu struct {
+	weights, momentsR, momentsG, momentsB, moments [wuTotal]float64
+}
+
+// wuBox is a box of the RGB color cube in histogram cells,
+// excluding the lower bounds and including the upper bounds.
+type wuBox struct {
+	r0, r1, g0, g1, b0, b1 int
+	vol                    int
+}
+
+// wuDirections are the directions in which a box can be cut.
+type wuDirections int
+
+const (
+	wuRed wuDirections = iota
+	wuGreen
+	wuBlue
+)
+
+// wuIndex returns the histogram index of the given cell.
+func wuIndex(r, g, b int) int {
+	return r*wuSide*wuSide + g*wuSide + b
+}
+
+// histogram fills in the histogram of the given opaque pixels.
+func (w *wu) histogram(pixels []color.RGBA) {
+	counts := map[color.RGBA]float64{}
+	for _, p := range pixels {
+		if p.A == 255 {
+			counts[p]++
+		}
+	}
+	shift := 8 - wuIndexBits
+	for c, n := range counts {
+		r, g, b := float64(c.R), float64(c.G), float64(c.B)
+		i := wuIndex(int(c.R>>shift)+1, int(c.G>>shift)+1, int(c.B>>shift)+1)
+		w.weights[i] += n
+		w.momentsR[i] += r * n
+		w.momentsG[i] += g * n
+		w.momentsB[i] += b * n
+		w.moments[i] += n * (r*r + g*g + b*b)
+	}
+}
+
+// computeMoments converts the histogram into cumulative moments, such that
+// each cell contains the sum of the cells from the origin to it.
+func (w *wu) computeMoments() {
+	for r := 1; r < wuSide; r++ {
+		var area, areaR, areaG, areaB, area2 [wuSide]float64
+		for g := 1; g < wuSide; g++ {
+			var line, lineR, lineG, lineB, line2 float64
+			for b := 1; b < wuSide; b++ {
+				i := wuIndex(r, g, b)
+				line += w.weights[i]
+				lineR += w.momentsR[i]
+				lineG += w.momentsG[i]
+				lineB += w.momentsB[i]
+				line2 += w.moments[i]
+
+				area[b] += line
+				areaR[b] += lineR
+				areaG[b] += lineG
+				areaB[b] += lineB
+				area2[b] += line2
+
+				pi := wuIndex(r-1, g, b)
+				w.weights[i] = w.weights[pi] + area[b]
+				w.momentsR[i] = w.momentsR[pi] + areaR[b]
+				w.momentsG[i] = w.momentsG[pi] + areaG[b]
+				w.momentsB[i] = w.momentsB[pi] + areaB[b]
+				w.moments[i] = w.moments[pi] + area2[b]
+			}
+		}
+	}
+}
+
+// createBoxes repeatedly cuts the box with the highest variance
+// until there are the given number of boxes or no more boxes can be cut.
+func (w *wu) createBoxes(maxColors int) []wuBox {
+	boxes := make([]wuBox, maxColors)
+	variances := make([]float64, maxColors)
+	boxes[0] = wuBox{r1: wuSide - 1, g1: wuSide - 1, b1: wuSide - 1}
+	n := maxColors
+	next := 0
+	for i := 1; i < maxColors; i++ {
+		if w.cut(&boxes[next], &boxes[i]) {
+			variances[next] = w.boxVariance(boxes[next])
+			variances[i] = w.boxVariance(boxes[i])
+		} else {
+			variances[next] = 0
+			i--
+		}
+		next = 0
+		temp := variances[0]
+		for j := 1; j <= i; j++ {
+			if variances[j] > temp {
+				temp = variances[j]
+				next = j
+			}
+		}
+		if temp <= 0 {
+			n = i + 1
+			break
+		}
+	}
+	return boxes[:n]
+}
+
+// boxVariance returns the variance of the colors in the given box,
+// or 0 if the box is a single cell.
+func (w *wu) boxVariance(b wuBox) float64 {
+	if b.vol <= 1 {
+		return 0
+	}
+	dr := w.volume(b, w.momentsR[:])
+	dg := w.volume(b, w.momentsG[:])
+	db := w.volume(b, w.momentsB[:])
+	return w.volume(b, w.moments[:]) - (dr*dr+dg*dg+db*db)/w.volume(b, w.weights[:])
+}
+
+// cut cuts the first given box into itself and the second given box at the
+// point that minimizes their variance, returning false if it can not be cut.
+func (w *wu) cut(one, two *wuBox) bool {
+	wholeR := w.volume(*one, w.momentsR[:])
+	wholeG := w.volume(*one, w.momentsG[:])
+	wholeB := w.volume(*one, w.momentsB[:])
+	wholeW := w.volume(*one, w.weights[:])
+
+	cutR, maxR := w.maximize(*one, wuRed, one.r0+1, one.r1, wholeR, wholeG, wholeB, wholeW)
+	cutG, maxG := w.maximize(*one, wuGreen, one.g0+1, one.g1, wholeR, wholeG, wholeB, wholeW)
+	cutB, maxB := w.maximize(*one, wuBlue, one.b0+1, one.b1, wholeR, wholeG, wholeB, wholeW)
+
+	two.r1, two.g1, two.b1 = one.r1, one.g1, one.b1
+	switch {
+	case maxR >= maxG && maxR >= maxB:
+		if cutR < 0 {
+			return false
+		}
+		one.r1 = cutR
+		two.r0, two.g0, two.b0 = one.r1, one.g0, one.b0
+	case maxG >= maxR && maxG >= maxB:
+		one.g1 = cutG
+		two.r0, two.g0, two.b0 = one.r0, one.g1, one.b0
+	default:
+		one.b1 = cutB
+		two.r0, two.g0, two.b0 = one.r0, one.g0, one.b1
+	}
+	one.vol = (one.r1 - one.r0) * (one.g1 - one.g0) * (one.b1 - one.b0)
+	two.vol = (two.r1 - two.r0) * (two.g1 - two.g0) * (two.b1 - two.b0)
+	return true
+}
+
+// maximize returns the position in the given range at which to cut the given
+// box in the given direction to maximize the sum of the squared means of the
+// two resulting boxes (which minimizes their variance), and that sum.
+// It returns a position of -1 if the box can not be cut.
+func (w *wu) maximize(b wuBox, dir wuDirections, first, last int, wholeR, wholeG, wholeB, wholeW float64) (int, float64) {
+	bottomR := w.bottom(b, dir, w.momentsR[:])
+	bottomG := w.bottom(b, dir, w.momentsG[:])
+	bottomB := w.bottom(b, dir, w.momentsB[:])
+	bottomW := w.bottom(b, dir, w.weights[:])
+
+	max := 0.0
+	cut := -1
+	for i := first; i < last; i++ {
+		halfR := bottomR + w.top(b, dir, i, w.momentsR[:])
+		halfG := bottomG + w.top(b, dir, i, w.momentsG[:])
+		halfB := bottomB + w.top(b, dir, i, w.momentsB[:])
+		halfW := bottomW + w.top(b, dir, i, w.weights[:])
+		if halfW == 0 {
+			continue
+		}
+		temp := (halfR*halfR + halfG*halfG + halfB*halfB) / halfW
+
+		halfR, halfG, halfB, halfW = wholeR-halfR, wholeG-halfG, wholeB-halfB, wholeW-halfW
+		if halfW == 0 {
+			continue
+		}
+		temp += (halfR*halfR + halfG*halfG + halfB*halfB) / halfW
+		if temp > max {
+			max = temp
+			cut = i
+		}
+	}
+	return cut, max
+}
+
+// volume returns the sum of the given moment over the given box.
+func (w *wu) volume(b wuBox, m []float64) float64 {
+	return m[wuIndex(b.r1, b.g1, b.b1)] -
+		m[wuIndex(b.r1, b.g1, b.b0)] -
+		m[wuIndex(b.r1, b.g0, b.b1)] +
+		m[wuIndex(b.r1, b.g0, b.b0)] -
+		m[wuIndex(b.r0, b.g1, b.b1)] +
+		m[wuIndex(b.r0, b.g1, b.b0)] +
+		m[wuIndex(b.r0, b.g0, b.b1)] -
+		m[wuIndex(b.r0, b.g0, b.b0)]
+}
+
+// bottom returns the part of the sum of the given moment over the given box
+// that is below its lower bound in the given direction, negated.
+func (w *wu) bottom(b wuBox, dir wuDirections, m []float64) float64 {
+	switch dir {
+	case wuRed:
+		return -m[wuIndex(b.r0, b.g1, b.b1)] +
+			m[wuIndex(b.r0, b.g1, b.b0)] +
+			m[wuIndex(b.r0, b.g0, b.b1)] -
+			m[wuIndex(b.r0, b.g0, b.b0)]
+	case wuGreen:
+		return -m[wuIndex(b.r1, b.g0, b.b1)] +
+			m[wuIndex(b.r1, b.g0, b.b0)] +
+			m[wuIndex(b.r0, b.g0, b.b1)] -
+			m[wuIndex(b.r0, b.g0, b.b0)]
+	}
+	return -m[wuIndex(b.r1, b.g1, b.b0)] +
+		m[wuIndex(b.r1, b.g0, b.b0)] +
+		m[wuIndex(b.r0, b.g1, b.b0)] -
+		m[wuIndex(b.r0, b.g0, b.b0)]
+}
+
+// top returns the sum of the given moment over the given box up to
+// the given position in the given direction, excluding the part that
+// is below its lower bound in that direction (see [wu.bottom]).
+func (w *wu) top(b wuBox, dir wuDirections, pos int, m []float64) float64 {
+	switch dir {
+	case wuRed:
+		return m[wuIndex(pos, b.g1, b.b1)] -
+			m[wuIndex(pos, b.g1, b.b0)] -
+			m[wuIndex(pos, b.g0, b.b1)] +
+			m[wuIndex(pos, b.g0, b.b0)]
+	case wuGreen:
+		return m[wuIndex(b.r1, pos, b.b1)] -
+			m[wuIndex(b.r1, pos, b.b0)] -
+			m[wuIndex(b.r0, pos, b.b1)] +
+			m[wuIndex(b.r0, pos, b.b0)]
+	}
+	return m[wuIndex(b.r1, b.g1, pos)] -
+		m[wuIndex(b.r1, b.g0, pos)] -
+		m[wuIndex(b.r0, b.g1, pos)] +
+		m[wuIndex(b.r0, b.g0, pos)]
+}